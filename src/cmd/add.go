@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"github.com/spf13/cobra"
+	"github.com/whywhathow/jenv/internal/config"
 	"github.com/whywhathow/jenv/internal/java"
 	"github.com/whywhathow/jenv/internal/style"
 )
@@ -13,26 +15,55 @@ var addCmd = &cobra.Command{
 	Long: `Add a new Java JDK to the environment.
 
 This command allows you to register a new Java JDK installation
-by providing a name and the path to the JDK installation directory.`,
+by providing a name and the path to the JDK installation directory.
+
+JDKs built for another CPU architecture than this machine are refused,
+unless they are registered with --cross (e.g. to keep a jlink target
+around). 'jenv use' refuses to switch to a cross-target JDK.
+
+By default the directory must be a full JDK with bin/javac. Use
+--kind jre or --kind custom-image to register a JRE, a jlink image
+or another runtime that only ships bin/java.`,
 	Example: `  jenvadd jdk8 "C:\Program Files\Java\jdk1.8.0_291"
-  jenvadd -f jdk11 "C:\Program Files\Java\jdk-11.0.12"`,
+  jenvadd -f jdk11 "C:\Program Files\Java\jdk-11.0.12"
+  jenv add --cross jdk21-x64 /opt/cross/jdk-21-x64
+  jenv add --kind jre jre17 /usr/lib/jvm/java-17-openjdk-jre`,
 	Args: cobra.ExactArgs(2),
 	Run:  runAdd,
 }
 
+var (
+	addCrossTarget bool
+	addKind        string
+)
+
 func init() {
 	rootCmd.AddCommand(addCmd)
+	addCmd.Flags().BoolVar(&addCrossTarget, "cross", false, "Register a JDK built for another CPU architecture as a cross-target")
+	addCmd.Flags().StringVar(&addKind, "kind", config.KindJDK, "Runtime kind: jdk, jre or custom-image")
 }
 
 func runAdd(cmd *cobra.Command, args []string) {
 	name := args[0]
 	path := args[1]
 
+	if !java.ValidKind(addKind) {
+		fmt.Printf("%s: unknown kind %q (use jdk, jre or custom-image)\n", style.Error.Render("Error"), addKind)
+		return
+	}
+
 	// Add JDK
-	if err := java.AddJDK(name, path); err != nil {
+	opts := java.AddOptions{CrossTarget: addCrossTarget, Kind: addKind}
+	if err := java.AddJDKWithOptions(name, path, opts); err != nil {
 		fmt.Printf("%s: %v\n",
 			style.Error.Render("Failed to add JDK"),
 			style.Error.Render(err.Error()))
+		if errors.Is(err, java.ErrArchMismatch) {
+			fmt.Println(style.Info.Render("Use --cross to register it as a cross-target JDK anyway"))
+		}
+		if errors.Is(err, java.ErrNoCompiler) {
+			fmt.Println(style.Info.Render("Use --kind jre or --kind custom-image to register a runtime without javac"))
+		}
 		return
 	}
 
@@ -40,4 +71,11 @@ func runAdd(cmd *cobra.Command, args []string) {
 		style.Success.Render("Successfully added JDK"),
 		style.Name.Render(name),
 		style.Path.Render(path))
+	// 通过模拟运行的其他架构 JDK（如 Rosetta）可以注册，但需要提示
+	if jdks, err := java.ListJdks(); err == nil {
+		if warning, _ := java.CheckArch(jdks[name]); warning != "" {
+			fmt.Printf("%s: %s\n", style.Warning.Render("Warning"), style.Warning.Render(warning))
+		}
+	}
+	refreshShims()
 }
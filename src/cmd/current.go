@@ -1,31 +1,64 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
+	"github.com/whywhathow/jenv/internal/config"
 	"github.com/whywhathow/jenv/internal/java"
 	"github.com/whywhathow/jenv/internal/style"
 )
 
 var currentCmd = &cobra.Command{
 	Aliases: []string{"cur", "now"},
-	Use:     "current",
+	Use:     "current [selector]",
 	Short:   "Show the current JDK",
 	Long: `Show the current JDK configuration.
 
 This command displays the name and path of the currently active JDK.
-If no JDK is currently set, it will prompt you to configure one first.`,
+If no JDK is currently set, it will prompt you to configure one first.
+
+With a name or selector argument (see 'jenv use --help') it shows which
+registered JDK that expression resolves to, and why, without switching.
+
+With --origin it shows the JDK in effect for the current directory and
+what decided it: the JENV_VERSION environment variable, the nearest
+.java-version file, or the global JDK set by 'jenv use'.`,
 	Example: `jenv current
 jenv cur
-jenv now`,
-	Run: runCurrent,
+jenv now
+jenv current temurin@21
+jenv current --origin`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runCurrent,
 }
 
+var currentOrigin bool
+
 func init() {
+	currentCmd.Flags().BoolVar(&currentOrigin, "origin", false, "Show the JDK in effect for the current directory and where it was set")
 	rootCmd.AddCommand(currentCmd)
 }
 
 func runCurrent(cmd *cobra.Command, args []string) {
+	if currentOrigin {
+		runCurrentOrigin()
+		return
+	}
+	if len(args) == 1 {
+		res, err := java.ResolveJDK(args[0])
+		if err != nil {
+			fmt.Printf("%s: %s\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
+			return
+		}
+		fmt.Println(style.Header.Render("Resolved JDK"))
+		fmt.Printf("%s: %s\n", style.Name.Render("Reason"), style.Info.Render(res.Reason))
+		printJDKDetails(res.JDK)
+		return
+	}
+
 	// Get current JDK
 	currentJDK, err := java.GetCurrentJDK()
 	if err != nil {
@@ -36,12 +69,62 @@ func runCurrent(cmd *cobra.Command, args []string) {
 
 	// Display header
 	fmt.Println(style.Header.Render("Current JDK Configuration"))
+	printJDKDetails(currentJDK)
+}
 
-	// Display current JDK info
+// runCurrentOrigin shows the JDK in effect for the working directory and what selected it
+func runCurrentOrigin() {
+	dir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("%s: %s\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
+		return
+	}
+	active, err := java.ResolveActive(dir, os.Getenv)
+	if errors.Is(err, java.ErrNoJDKConfigured) {
+		fmt.Println(style.Error.Render("No JDK is currently configured."))
+		fmt.Println(style.Input.Render("Please use 'jenv use <name>' or 'jenv local <name>' to set a JDK first."))
+		return
+	}
+	if err != nil {
+		fmt.Printf("%s: %s\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
+		return
+	}
+
+	fmt.Println(style.Header.Render("Active JDK"))
+	fmt.Printf("%s: %s\n", style.Name.Render("Origin"), style.Info.Render(active.Origin))
+	fmt.Printf("%s: %s\n", style.Name.Render("Set by"), style.Path.Render(active.Source))
+	if active.Expr != active.JDK.Name {
+		fmt.Printf("%s: %s\n", style.Name.Render("Reason"), style.Info.Render(active.Reason))
+	}
+	printJDKDetails(active.JDK)
+}
+
+// printJDKDetails displays the name, path and release metadata of a JDK
+func printJDKDetails(jdk config.JDK) {
 	fmt.Printf("%s: %s\n",
 		style.Name.Render("Name"),
-		style.Current.Render(currentJDK.Name))
+		style.Current.Render(jdk.Name))
 	fmt.Printf("%s: %s\n",
 		style.Name.Render("Path"),
-		style.Current.Render(currentJDK.Path))
+		style.Current.Render(jdk.Path))
+	if jdk.HasMetadata() {
+		fmt.Printf("%s: %s\n",
+			style.Name.Render("Version"),
+			style.Current.Render(displayVersion(jdk)))
+	}
+	if jdk.Implementor != "" {
+		fmt.Printf("%s: %s\n",
+			style.Name.Render("Implementor"),
+			style.Current.Render(jdk.Implementor))
+	}
+	if jdk.Vendor != "" {
+		fmt.Printf("%s: %s\n",
+			style.Name.Render("Vendor"),
+			style.Current.Render(jdk.Vendor))
+	}
+	if jdk.Source != "" {
+		fmt.Printf("%s: %s\n",
+			style.Name.Render("Source"),
+			style.Current.Render(jdk.Source))
+	}
 }
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/whywhathow/jenv/internal/java"
+	"github.com/whywhathow/jenv/internal/project"
+	"github.com/whywhathow/jenv/internal/style"
+)
+
+var detectCmd = &cobra.Command{
+	Use:   "detect [dir]",
+	Short: "Show the Java version the project's build files require",
+	Long: `Read the build files of the project in the current directory (or dir)
+and print the Java version they require, together with the registered JDK
+'jenv use --detect' would pick for it.
+
+The nearest of these files that states a version is used, walking up from
+the directory:
+  gradle/gradle-daemon-jvm.properties   toolchainVersion (and toolchainVendor)
+  build.gradle.kts, build.gradle        java.toolchain.languageVersion,
+                                        kotlin jvmToolchain, or
+                                        source/targetCompatibility
+  pom.xml                               <release>, <target> or <source> of the
+                                        maven-compiler-plugin, or the
+                                        maven.compiler.* properties
+
+A JDK of exactly the required version is preferred; otherwise the oldest
+newer JDK is picked. Gradle toolchains (toolchainVersion, languageVersion,
+jvmToolchain) only accept the exact version, so no newer JDK is used for
+them. A requested vendor is preferred; when none of that vendor is
+registered another one is used and the reason says so.`,
+	Example: `  jenv detect
+  jenv detect ../service
+  jenv use --detect`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runDetect,
+}
+
+func init() {
+	rootCmd.AddCommand(detectCmd)
+}
+
+func runDetect(cmd *cobra.Command, args []string) {
+	dir := "."
+	if len(args) == 1 {
+		dir = args[0]
+	}
+	req, ok := detectRequirement(dir)
+	if !ok {
+		os.Exit(1)
+	}
+
+	fmt.Println(style.Header.Render("Required Java version"))
+	fmt.Printf("%s: %s\n", style.Name.Render("Java"), style.Current.Render(fmt.Sprint(req.Level)))
+	if req.Vendor != "" {
+		fmt.Printf("%s: %s\n", style.Name.Render("Vendor"), style.Info.Render(req.Vendor))
+	}
+	fmt.Printf("%s: %s\n", style.Name.Render("Set by"), style.Path.Render(req.String()+" ("+req.Key+")"))
+
+	res, err := java.ResolveRequirement(req)
+	if err != nil {
+		fmt.Printf("%s: %s\n", style.Warning.Render("No match"), style.Warning.Render(err.Error()))
+		return
+	}
+	fmt.Printf("%s: %s\n", style.Name.Render("Best match"), style.Current.Render(res.JDK.Name))
+	fmt.Printf("%s: %s\n", style.Name.Render("Reason"), style.Info.Render(res.Reason))
+}
+
+// detectRequirement reads the required Java level for dir, reporting failures
+func detectRequirement(dir string) (project.Requirement, bool) {
+	req, err := project.DetectRequirement(dir)
+	if errors.Is(err, project.ErrNoRequirement) {
+		fmt.Println(style.Warning.Render("No pom.xml, build.gradle(.kts) or gradle-daemon-jvm.properties states a Java version."))
+		return req, false
+	}
+	if err != nil {
+		fmt.Printf("%s: %s\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
+		return req, false
+	}
+	return req, true
+}
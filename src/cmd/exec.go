@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+	"github.com/whywhathow/jenv/internal/java"
+)
+
+var execCmd = &cobra.Command{
+	Use:   "exec <name|selector> [--] <command> [args...]",
+	Short: "Run a command under a JDK without switching",
+	Long: `Run a single command with a registered JDK, leaving the current JDK alone.
+
+The command gets JAVA_HOME set to the JDK, the JDK's bin directory first on
+PATH and the bin directories of other JDKs removed from PATH. JENV_VERSION
+is set as well, so jenv shims started by the command use the same JDK.
+
+Nothing is changed on disk: the current JDK, the JAVA_HOME symlink and
+shell configuration files are left as they are. The command's exit code
+is passed back unchanged.`,
+	Example: `  jenv exec 8 -- mvn clean verify
+  jenv exec temurin@17 -- ./gradlew build
+  jenv exec jdk21 java -version`,
+	Args:             cobra.MinimumNArgs(2),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+	Run:              runExec,
+}
+
+func init() {
+	// 选择器之后的参数原样交给命令
+	execCmd.Flags().SetInterspersed(false)
+	rootCmd.AddCommand(execCmd)
+}
+
+func runExec(cmd *cobra.Command, args []string) {
+	command := args[1:]
+	if command[0] == "--" {
+		command = command[1:]
+	}
+	if len(command) == 0 {
+		execFail(errors.New("no command given"), 2)
+	}
+
+	res, err := java.ResolveJDK(args[0])
+	if err != nil {
+		execFail(err, 1)
+	}
+	warning, err := java.CheckArch(res.JDK)
+	if err != nil {
+		execFail(err, 1)
+	}
+	if warning != "" {
+		fmt.Fprintf(os.Stderr, "jenv: warning: %s\n", warning)
+	}
+
+	if err := java.ExecCommand(res.JDK, command); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			execFail(err, 127)
+		}
+		execFail(err, 126)
+	}
+}
+
+// execFail reports on stderr so the command's own output stays clean
+func execFail(err error, code int) {
+	fmt.Fprintf(os.Stderr, "jenv exec: %v\n", err)
+	os.Exit(code)
+}
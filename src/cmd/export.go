@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/whywhathow/jenv/internal/export"
+	"github.com/whywhathow/jenv/internal/java"
+	"github.com/whywhathow/jenv/internal/style"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export registered JDKs to build tools and IDEs",
+	Long: `Write the JDKs registered with jenv into the configuration of other tools,
+so they do not have to be maintained by hand.`,
+}
+
+var (
+	mavenFile  string
+	mavenCheck bool
+
+	mavenToolchainsCmd = &cobra.Command{
+		Use:   "maven-toolchains",
+		Short: "Write registered JDKs to Maven's toolchains.xml",
+		Long: `Write a <toolchain> of type jdk for every registered JDK to Maven's
+toolchains.xml (~/.m2/toolchains.xml by default), for use with the
+maven-toolchains-plugin.
+
+Each toolchain provides the JDK's version (1.8, 11, 17, ...), its vendor and
+an id of the form jenv:<name>. Toolchains you added yourself are kept as
+they are; entries pointing at the home of a registered JDK are taken over.
+JREs, runtime images and cross-target JDKs are skipped.
+
+With --check nothing is written: the differences between the file and the
+registered JDKs are listed and the exit code is 1 when they differ.`,
+		Example: `  jenv export maven-toolchains
+  jenv export maven-toolchains --check
+  jenv export maven-toolchains --file ./toolchains.xml`,
+		Args: cobra.NoArgs,
+		Run:  runMavenToolchains,
+	}
+)
+
+var (
+	gradleProject    string
+	gradleAutoDetect bool
+	gradleCheck      bool
+
+	gradleCmd = &cobra.Command{
+		Use:   "gradle",
+		Short: "Write registered JDKs to gradle.properties",
+		Long: `Set org.gradle.java.installations.paths in gradle.properties to the homes of
+all registered JDKs, so Gradle's toolchain support finds them.
+
+The Gradle user home (~/.gradle or $GRADLE_USER_HOME) is updated by default;
+--project <dir> writes the gradle.properties of a project instead; pass
+--project . for the current directory. Other properties in the file are
+kept. The installations property is owned by jenv: run the command again
+after 'jenv add' or 'jenv remove' to bring it back in sync. JREs, runtime images and cross-target JDKs are skipped.
+
+--auto-detect=false also sets org.gradle.java.installations.auto-detect,
+so Gradle only uses the JDKs jenv knows about.
+
+With --check nothing is written: the differences are listed and the exit
+code is 1 when the file is out of sync.`,
+		Example: `  jenv export gradle
+  jenv export gradle --auto-detect=false
+  jenv export gradle --project .
+  jenv export gradle --project ../service --check`,
+		Args: cobra.NoArgs,
+		Run:  runGradle,
+	}
+)
+
+var (
+	ideTargets   []string
+	idePath      string
+	ideWorkspace string
+
+	ideCmd = &cobra.Command{
+		Use:   "ide",
+		Short: "Write registered JDKs to IntelliJ IDEA, VS Code or Eclipse",
+		Long: `Add the registered JDKs to the SDK configuration of IDEs:
+
+  intellij  options/jdk.table.xml of every IntelliJ IDEA configuration
+            directory, with module class roots and src.zip source roots
+  vscode    java.configuration.runtimes in the VS Code user settings.json,
+            one runtime per Java version named JavaSE-N (the newest JDK of
+            each version is used)
+  eclipse   installed JREs of an Eclipse workspace (~/eclipse-workspace by
+            default, see --workspace)
+
+Existing entries are merged: an SDK the IDE already has for the same home
+is left alone, one with the same name but another home is updated, and
+everything else in the file is kept. JREs, runtime images and cross-target
+JDKs are skipped.
+
+Close the IDE first: IntelliJ and Eclipse overwrite these files on exit.`,
+		Example: `  jenv export ide --target intellij
+  jenv export ide --target vscode,eclipse
+  jenv export ide --target eclipse --workspace ~/work/eclipse
+  jenv export ide --target vscode --path .vscode/settings.json`,
+		Args: cobra.NoArgs,
+		Run:  runIDE,
+	}
+)
+
+func init() {
+	ideCmd.Flags().StringSliceVar(&ideTargets, "target", nil, "IDEs to update: intellij, vscode, eclipse")
+	ideCmd.Flags().StringVar(&idePath, "path", "", "Configuration file to update instead of the default (single target only)")
+	ideCmd.Flags().StringVar(&ideWorkspace, "workspace", "", "Eclipse workspace directory (default ~/eclipse-workspace)")
+	_ = ideCmd.MarkFlagRequired("target")
+	exportCmd.AddCommand(ideCmd)
+
+	gradleCmd.Flags().StringVar(&gradleProject, "project", "", "Update <dir>/gradle.properties instead of the Gradle user home")
+	gradleCmd.Flags().BoolVar(&gradleAutoDetect, "auto-detect", true, "Also set org.gradle.java.installations.auto-detect")
+	gradleCmd.Flags().BoolVar(&gradleCheck, "check", false, "Report drift instead of writing the file")
+	exportCmd.AddCommand(gradleCmd)
+
+	mavenToolchainsCmd.Flags().StringVar(&mavenFile, "file", "", "Path of toolchains.xml (default ~/.m2/toolchains.xml)")
+	mavenToolchainsCmd.Flags().BoolVar(&mavenCheck, "check", false, "Report drift instead of writing the file")
+	exportCmd.AddCommand(mavenToolchainsCmd)
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runMavenToolchains(cmd *cobra.Command, args []string) {
+	path := mavenFile
+	if path == "" {
+		var err error
+		if path, err = export.DefaultToolchainsPath(); err != nil {
+			exitWithError(err)
+		}
+	}
+
+	jdks, err := java.ListJdks()
+	if err != nil {
+		exitWithError(err)
+	}
+	toolchains := export.MavenToolchains(jdks)
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		exitWithError(err)
+	}
+
+	if mavenCheck {
+		drift, err := export.CheckToolchains(existing, toolchains)
+		if err != nil {
+			exitWithError(fmt.Errorf("%s: %v", path, err))
+		}
+		if drift.InSync() {
+			fmt.Printf("%s: %s\n", style.Success.Render("In sync"), style.Path.Render(path))
+			return
+		}
+		fmt.Printf("%s: %s\n", style.Warning.Render("Out of sync"), style.Path.Render(path))
+		printDrift("Missing", drift.Added)
+		printDrift("Changed", drift.Changed)
+		printDrift("No longer registered", drift.Removed)
+		fmt.Println(style.Info.Render("Run 'jenv export maven-toolchains' to update it."))
+		os.Exit(1)
+	}
+
+	merged, err := export.MergeToolchains(existing, toolchains)
+	if err != nil {
+		exitWithError(fmt.Errorf("%s: %v", path, err))
+	}
+	if err := writeExportFile(path, merged); err != nil {
+		exitWithError(err)
+	}
+	fmt.Printf("%s: %d JDK toolchains → %s\n",
+		style.Success.Render("Exported"),
+		len(toolchains),
+		style.Path.Render(path))
+}
+
+func runGradle(cmd *cobra.Command, args []string) {
+	path, err := export.GradlePropertiesPath(gradleProject)
+	if err != nil {
+		exitWithError(err)
+	}
+	var autoDetect *bool
+	if cmd.Flags().Changed("auto-detect") {
+		autoDetect = &gradleAutoDetect
+	}
+
+	jdks, err := java.ListJdks()
+	if err != nil {
+		exitWithError(err)
+	}
+	paths := export.GradlePaths(jdks)
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		exitWithError(err)
+	}
+
+	if gradleCheck {
+		drift := export.CheckGradleProperties(existing, paths, autoDetect)
+		if drift.InSync() {
+			fmt.Printf("%s: %s\n", style.Success.Render("In sync"), style.Path.Render(path))
+			return
+		}
+		fmt.Printf("%s: %s\n", style.Warning.Render("Out of sync"), style.Path.Render(path))
+		printDrift("Missing", drift.Added)
+		printDrift("Changed", drift.Changed)
+		printDrift("No longer registered", drift.Removed)
+		fmt.Println(style.Info.Render("Run 'jenv export gradle' to update it."))
+		os.Exit(1)
+	}
+
+	if err := writeExportFile(path, export.UpdateGradleProperties(existing, paths, autoDetect)); err != nil {
+		exitWithError(err)
+	}
+	fmt.Printf("%s: %d JDK paths → %s\n",
+		style.Success.Render("Exported"),
+		len(paths),
+		style.Path.Render(path))
+}
+
+func runIDE(cmd *cobra.Command, args []string) {
+	for _, target := range ideTargets {
+		switch target {
+		case export.TargetIntelliJ, export.TargetVSCode, export.TargetEclipse:
+		default:
+			exitWithError(fmt.Errorf("unknown target '%s', expected intellij, vscode or eclipse", target))
+		}
+	}
+	if idePath != "" && len(ideTargets) > 1 {
+		exitWithError(fmt.Errorf("--path can only be used with a single --target"))
+	}
+
+	jdkMap, err := java.ListJdks()
+	if err != nil {
+		exitWithError(err)
+	}
+	jdks := export.IDEJDKs(jdkMap)
+	if len(jdks) == 0 {
+		fmt.Println(style.Warning.Render("No JDKs to export. Register one with 'jenv add' first."))
+		return
+	}
+
+	failed := false
+	for _, target := range ideTargets {
+		paths, err := ideConfigFiles(target)
+		if err != nil {
+			exitWithError(err)
+		}
+		if len(paths) == 0 {
+			fmt.Printf("%s: no %s configuration found, use --path to name the file\n",
+				style.Warning.Render("Skipped"), target)
+			continue
+		}
+		for _, path := range paths {
+			result, err := exportIDE(target, path, jdks)
+			if err != nil {
+				fmt.Printf("%s: %s: %s\n", style.Error.Render("Error"), style.Path.Render(path), style.Error.Render(err.Error()))
+				failed = true
+				continue
+			}
+			status := style.Success.Render("Exported")
+			if !result.Changed() {
+				status = style.Success.Render("Up to date")
+			}
+			fmt.Printf("%s: %s\n", status, style.Path.Render(path))
+			printDrift("Added", result.Added)
+			printDrift("Updated", result.Updated)
+			printDrift("Already present", result.Unchanged)
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// ideConfigFiles returns the files to update for target
+func ideConfigFiles(target string) ([]string, error) {
+	if idePath != "" {
+		return []string{idePath}, nil
+	}
+	switch target {
+	case export.TargetIntelliJ:
+		return export.IntelliJConfigFiles()
+	case export.TargetVSCode:
+		path, err := export.VSCodeSettingsPath()
+		return []string{path}, err
+	default:
+		path, err := export.EclipsePrefsPath(ideWorkspace)
+		return []string{path}, err
+	}
+}
+
+// exportIDE merges the JDKs into one IDE configuration file
+func exportIDE(target, path string, jdks []export.IDEJDK) (export.IDEResult, error) {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return export.IDEResult{}, err
+	}
+
+	var merged []byte
+	var result export.IDEResult
+	switch target {
+	case export.TargetIntelliJ:
+		home, _ := os.UserHomeDir()
+		merged, result, err = export.MergeIntelliJ(existing, jdks, home)
+	case export.TargetVSCode:
+		merged, result, err = export.MergeVSCode(existing, jdks)
+	default:
+		merged, result, err = export.MergeEclipse(existing, jdks, time.Now().UnixMilli())
+	}
+	result.Path = path
+	if err != nil || !result.Changed() {
+		return result, err
+	}
+	return result, writeExportFile(path, merged)
+}
+
+func printDrift(label string, names []string) {
+	if len(names) > 0 {
+		fmt.Printf("  %s: %s\n", style.Name.Render(label), style.Info.Render(strings.Join(names, ", ")))
+	}
+}
+
+// writeExportFile replaces path with data, creating its directory if needed
+// and keeping the permissions of an existing file
+func writeExportFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	tmp := path + ".jenv-tmp"
+	if err := os.WriteFile(tmp, data, mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func exitWithError(err error) {
+	fmt.Printf("%s: %s\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
+	os.Exit(1)
+}
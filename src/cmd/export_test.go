@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestGradleProjectFlag(t *testing.T) {
+	// --project 后用空格分隔的目录应作为参数值，而不是多余的参数
+	defer func() { gradleProject, gradleCheck = "", false }()
+	for _, args := range [][]string{
+		{"--project", "../service", "--check"},
+		{"--project=../service", "--check"},
+	} {
+		gradleProject, gradleCheck = "", false
+		cmd, rest, err := rootCmd.Find(append([]string{"export", "gradle"}, args...))
+		if err != nil || cmd != gradleCmd {
+			t.Fatalf("%v: 找不到 export gradle 命令: %v", args, err)
+		}
+		if err := cmd.ParseFlags(rest); err != nil {
+			t.Fatalf("%v: 解析参数失败: %v", args, err)
+		}
+		if err := cmd.ValidateArgs(cmd.Flags().Args()); err != nil {
+			t.Errorf("%v: 参数校验失败: %v", args, err)
+		}
+		if gradleProject != "../service" || !gradleCheck {
+			t.Errorf("%v: project=%q check=%v", args, gradleProject, gradleCheck)
+		}
+	}
+}
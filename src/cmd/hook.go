@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/whywhathow/jenv/internal/java"
+	"github.com/whywhathow/jenv/internal/shell"
+	"github.com/whywhathow/jenv/internal/style"
+)
+
+var (
+	hookInstall bool
+
+	hookCmd = &cobra.Command{
+		Use:   "hook <bash|zsh|fish>",
+		Short: "Print a shell hook that switches the JDK when you change directory",
+		Long: `Print a hook that keeps JAVA_HOME and PATH in the live shell in line with
+the nearest .java-version file (and JENV_VERSION) as you change directory.
+
+  bash   runs from PROMPT_COMMAND
+  zsh    runs from chpwd
+  fish   runs on changes of PWD
+
+The hook remembers the last directory and JDK it applied, so it costs nothing
+until the directory changes, and only touches the environment when the JDK
+does. Outside projects the global JDK set by 'jenv use' applies.
+After editing .java-version in the current directory, run
+'unset JENV_HOOK_DIR' (fish: 'set -e JENV_HOOK_DIR') to re-resolve it.
+
+Load it from your shell configuration, or let --install add the line:
+  bash   eval "$(jenv hook bash)"        in ~/.bashrc
+  zsh    eval "$(jenv hook zsh)"         in ~/.zshrc
+  fish   jenv hook fish | source         in ~/.config/fish/config.fish`,
+		Example: `  eval "$(jenv hook bash)"
+  jenv hook zsh --install
+  jenv hook fish | source`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		// 输出会被 eval，跳过 root 的提示信息
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+		Run:              runHook,
+	}
+
+	hookEnvShell string
+
+	// hookEnvCmd is what the installed hook runs when the directory changes.
+	// Like the shims it skips the root checks and writes nothing but shell code to stdout.
+	hookEnvCmd = &cobra.Command{
+		Use:              "hook-env",
+		Short:            "Print the environment update for the current directory",
+		Hidden:           true,
+		Args:             cobra.NoArgs,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+		Run:              runHookEnv,
+	}
+)
+
+func init() {
+	hookCmd.Flags().BoolVar(&hookInstall, "install", false, "Add the hook to the shell's configuration file")
+	hookEnvCmd.Flags().StringVar(&hookEnvShell, "shell", "bash", "Shell to generate code for")
+	rootCmd.AddCommand(hookCmd)
+	rootCmd.AddCommand(hookEnvCmd)
+}
+
+func runHook(cmd *cobra.Command, args []string) {
+	sh, err := shell.GetSessionShellConfig(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
+		os.Exit(1)
+	}
+	self, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
+		os.Exit(1)
+	}
+	if resolved, err := filepath.EvalSymlinks(self); err == nil {
+		self = resolved
+	}
+
+	script, err := shell.HookScript(sh, self)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
+		os.Exit(1)
+	}
+	if !hookInstall {
+		fmt.Print(script)
+		return
+	}
+
+	line := fmt.Sprintf(`eval "$("%s" hook %s)"`, self, sh.Type)
+	if sh.Type == shell.Fish {
+		line = fmt.Sprintf(`"%s" hook fish | source`, self)
+	}
+	path, added, err := shell.InstallHook(sh.Type, line)
+	if err != nil {
+		fmt.Printf("%s: %s\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
+		return
+	}
+	if !added {
+		fmt.Printf("%s: %s\n", style.Info.Render("Hook already installed in"), style.Path.Render(path))
+		return
+	}
+	fmt.Printf("%s: %s\n", style.Success.Render("Hook installed in"), style.Path.Render(path))
+	fmt.Println(style.Info.Render("Open a new terminal for it to take effect."))
+}
+
+func runHookEnv(cmd *cobra.Command, args []string) {
+	sh, err := shell.GetSessionShellConfig(hookEnvShell)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "jenv: %v\n", err)
+		return
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "jenv: %v\n", err)
+		return
+	}
+
+	home := ""
+	active, err := java.ResolveActive(dir, os.Getenv)
+	switch {
+	case err == nil:
+		home = active.Home()
+	case active.Origin != java.OriginGlobal:
+		// 版本文件或 JENV_VERSION 无效时提示，但保持当前环境
+		fmt.Fprintf(os.Stderr, "jenv: %v\n", err)
+	}
+	if home == "" {
+		// 只更新缓存，避免每次提示符都重新解析
+		fmt.Println(sh.SetCommand(shell.HookDirKey, dir))
+		return
+	}
+
+	fmt.Print(shell.HookUpdate(sh, dir, home, java.JavaHomeVars(os.Environ(), home), os.LookupEnv))
+}
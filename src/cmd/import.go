@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/whywhathow/jenv/internal/java"
+	"github.com/whywhathow/jenv/internal/style"
+)
+
+var (
+	importFrom   string
+	importDryRun bool
+
+	importCmd = &cobra.Command{
+		Use:   "import --from <tool>",
+		Short: "Register JDKs installed by another version manager",
+		Long: `Register the JDKs another tool has installed, leaving their files where
+they are. Supported tools and where their JDKs are found:
+  sdkman        ~/.sdkman/candidates/java/<id>   ($SDKMAN_DIR)
+  asdf          ~/.asdf/installs/java/<id>       ($ASDF_DATA_DIR)
+  jabba         ~/.jabba/jdk/<id>                ($JABBA_HOME)
+  intellij      ~/.jdks/<vendor>-<version>, and on macOS
+                ~/Library/Java/JavaVirtualMachines/<id>
+  jenv-classic  ~/.jenv/versions/<alias>         ($JENV_ROOT)
+
+Each JDK is registered under the tool's identifier, e.g. 17.0.9-tem, with
+'@' replaced by '-' for jabba. When that name is taken the tool is put in
+front of it, e.g. sdkman-17.0.9-tem. jEnv keeps several aliases for one
+JDK; the most specific one is used. JDKs whose path is already
+registered are skipped.`,
+		Example: `  jenv import --from sdkman
+  jenv import --from intellij --dry-run`,
+		Args: cobra.NoArgs,
+		Run:  runImport,
+	}
+)
+
+func init() {
+	importCmd.Flags().StringVar(&importFrom, "from", "", "Tool to import from: "+strings.Join(java.ImportSources, ", "))
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Only list the JDKs that would be registered")
+	importCmd.MarkFlagRequired("from")
+	rootCmd.AddCommand(importCmd)
+}
+
+func runImport(cmd *cobra.Command, args []string) {
+	candidates, err := java.FindImports(importFrom)
+	if err != nil {
+		exitWithError(err)
+	}
+	fmt.Println(style.Header.Render("Importing JDKs from " + importFrom))
+	if len(candidates) == 0 {
+		fmt.Println(style.Input.Render("✨ No JDKs found."))
+		return
+	}
+
+	imported, skipped, failed := 0, 0, 0
+	for _, c := range candidates {
+		if c.Registered {
+			fmt.Printf("%s %s %s\n", style.Input.Render("↪ Already registered:"), style.Name.Render(c.ID), style.Path.Render(c.Path))
+			skipped++
+			continue
+		}
+		if importDryRun {
+			fmt.Printf("%s: %s → %s\n", style.Info.Render("• Would import"), style.Name.Render(c.Name), style.Path.Render(c.Path))
+			imported++
+			continue
+		}
+		name, err := java.ImportJDK(c)
+		if err != nil {
+			fmt.Printf("%s %s: %s\n", style.Error.Render("✖ Failed to import"), style.Name.Render(c.ID), style.Error.Render(err.Error()))
+			failed++
+			continue
+		}
+		fmt.Printf("%s: %s → %s\n", style.Success.Render("✔ Imported JDK"), style.Success.Render(name), style.Path.Render(c.Path))
+		imported++
+	}
+
+	label := "Imported"
+	if importDryRun {
+		label = "Would import"
+	}
+	fmt.Printf("\n%s: %d  %s: %d  %s: %d\n",
+		style.Success.Render(label), imported,
+		style.Input.Render("Already registered"), skipped,
+		style.Error.Render("Failed"), failed)
+	if imported > 0 && !importDryRun {
+		refreshShims()
+	}
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/whywhathow/jenv/internal/config"
+	"github.com/whywhathow/jenv/internal/install"
+	"github.com/whywhathow/jenv/internal/java"
+	"github.com/whywhathow/jenv/internal/provider"
+	"github.com/whywhathow/jenv/internal/style"
+	"github.com/whywhathow/jenv/internal/verify"
+)
+
+var (
+	installFile      string
+	installName      string
+	installSHA256    string
+	installSignature string
+
+	installCmd = &cobra.Command{
+		Use:   "install <selector> | --file <archive>",
+		Short: "Download and install a JDK, or install one from an archive",
+		Long: `Install a JDK into the jenv store (~/.jdks/store) and register it.
+
+With a selector such as temurin@21 or 17.0.9 the newest matching JDK for
+this machine is looked up in the catalog of a provider, downloaded and
+checked against its published SHA-256 checksum. Without a distribution,
+temurin is installed. Providers:
+  foojay   the Foojay Disco API (default); set install.foojay_url in
+           config.json to use an internal proxy of the API
+  mirror   a catalog.json in a local directory or on an internal HTTP
+           server, set with --mirror or install.mirror_url
+Downloads honour HTTPS_PROXY, HTTP_PROXY and NO_PROXY, or install.proxy.
+
+With --file a .tar.gz or .zip archive on disk is installed instead. It is
+checked first like 'jenv verify-archive' does: against --sha256 or a
+<file>.sha256 checksum file, and against a detached signature when one
+is given or found next to it. When there is neither, the JDK is still
+installed and a warning says the archive was not verified.
+
+The archive is extracted into the store. A single top-level directory is
+stripped, and so is the Contents/Home layout of macOS JDK bundles. File
+permissions and symbolic links are kept; entries that would be written
+outside the target directory are refused.
+
+The JDK is registered under a name made from its release file, such as
+temurin-17.0.9+9, unless --name is given.`,
+		Example: `  jenv install temurin@21
+  jenv install 17
+  jenv install corretto@17.0.9 --name jdk17
+  jenv install zulu@21 --mirror https://artifacts.example.com/jdks
+  jenv install --file OpenJDK17U-jdk_x64_linux_hotspot_17.0.9_9.tar.gz
+  jenv install --file jdk-21_windows-x64_bin.zip --name jdk21`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if installFile != "" && len(args) > 0 {
+				return fmt.Errorf("pass either a selector or --file, not both")
+			}
+			if installFile == "" && len(args) != 1 {
+				return fmt.Errorf("requires a selector such as temurin@21, or --file <archive>")
+			}
+			return nil
+		},
+		Run: runInstall,
+	}
+)
+
+// 选择 JDK 目录来源的参数，install 和 ls-remote 共用
+var (
+	providerName   string
+	providerMirror string
+)
+
+func init() {
+	installCmd.Flags().StringVar(&installFile, "file", "", "JDK archive (.tar.gz or .zip) to install")
+	installCmd.Flags().StringVar(&installSHA256, "sha256", "", "Expected SHA-256 of the --file archive (default from <file>.sha256)")
+	installCmd.Flags().StringVar(&installSignature, "signature", "", "Detached minisign or GPG signature of the --file archive")
+	installCmd.Flags().StringVar(&installName, "name", "", "Name to register the JDK under (default from its release file)")
+	addProviderFlags(installCmd)
+	rootCmd.AddCommand(installCmd)
+}
+
+func addProviderFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&providerName, "provider", "", "JDK catalog: foojay or mirror (default from config.json, else foojay)")
+	cmd.Flags().StringVar(&providerMirror, "mirror", "", "Directory or URL of a mirror catalog; implies --provider mirror")
+}
+
+// newProvider returns the catalog selected by config.json and the command line
+func newProvider() (provider.Provider, config.InstallSettings, error) {
+	var settings config.InstallSettings
+	if cfg, err := config.GetInstance(); err == nil {
+		settings = cfg.Install
+	}
+	name := providerName
+	if providerMirror != "" {
+		settings.MirrorURL = providerMirror
+		if name == "" {
+			name = provider.NameMirror
+		}
+	}
+	p, err := provider.New(settings, name)
+	return p, settings, err
+}
+
+func runInstall(cmd *cobra.Command, args []string) {
+	var result install.Result
+	var err error
+	if installFile != "" {
+		fmt.Printf("%s %s\n", style.Info.Render("Extracting"), style.Path.Render(installFile))
+		result, err = install.FromArchive(installFile, install.Options{
+			Name:      installName,
+			SHA256:    installSHA256,
+			Signature: installSignature,
+		})
+	} else {
+		result, err = installFromProvider(args[0])
+	}
+	if err != nil {
+		fmt.Printf("%s: %s\n", style.Error.Render("Failed to install JDK"), style.Error.Render(err.Error()))
+		switch {
+		case errors.Is(err, config.ErrJDKExists):
+			fmt.Println(style.Info.Render("Use --name to install it under another name"))
+		case errors.Is(err, java.ErrArchMismatch):
+			fmt.Println(style.Info.Render("The archive is built for another CPU architecture"))
+		case errors.Is(err, verify.ErrNoTrustedKey):
+			fmt.Println(style.Info.Render("Run 'jenv verify-archive --help' to see how to trust signing keys"))
+		case errors.Is(err, provider.ErrNoPackage):
+			fmt.Println(style.Info.Render("Run 'jenv ls-remote' to see what is available"))
+		}
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s: %s → %s\n",
+		style.Success.Render("Successfully installed JDK"),
+		style.Name.Render(result.Name),
+		style.Path.Render(result.Home))
+	if !result.Verify.Verified() {
+		// 没有校验和也没有签名时安装仍会成功，但需要让用户知道
+		fmt.Printf("%s: %s\n", style.Warning.Render("Warning"), style.Warning.Render("the archive was not verified"))
+		if installFile != "" {
+			fmt.Printf("%s: %s\n", style.Name.Render("Checksum"), style.Warning.Render(checksumNotChecked(installFile)))
+		} else {
+			fmt.Printf("%s: %s\n", style.Name.Render("Checksum"), style.Warning.Render("not checked (the catalog publishes no checksum)"))
+		}
+		fmt.Printf("%s: %s\n", style.Name.Render("Signature"), style.Warning.Render(signatureNotChecked))
+	}
+	refreshShims()
+}
+
+func installFromProvider(expr string) (install.Result, error) {
+	p, settings, err := newProvider()
+	if err != nil {
+		return install.Result{}, err
+	}
+	ctx := context.Background()
+	pkg, err := provider.Find(ctx, p, expr)
+	if err != nil {
+		return install.Result{}, err
+	}
+
+	action := "Downloading"
+	if !strings.HasPrefix(pkg.URL, "http://") && !strings.HasPrefix(pkg.URL, "https://") {
+		action = "Extracting"
+	}
+	fmt.Printf("%s %s %s (%s)\n", style.Info.Render(action), style.Name.Render(pkg.Vendor), style.Current.Render(pkg.Version), style.Path.Render(pkg.URL))
+	client, err := provider.HTTPClient(settings.Proxy)
+	if err != nil {
+		return install.Result{}, err
+	}
+	return install.FromPackage(ctx, client, pkg, install.Options{Name: installName})
+}
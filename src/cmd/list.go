@@ -7,8 +7,10 @@ import (
 	"github.com/whywhathow/jenv/internal/config"
 	"github.com/whywhathow/jenv/internal/java"
 	"github.com/whywhathow/jenv/internal/style"
+	"math"
 	"os"
 	"sort"
+	"strings"
 )
 
 var listCmd = &cobra.Command{
@@ -18,14 +20,39 @@ var listCmd = &cobra.Command{
 	Long: `List all Java JDKs registered in the environment.
 
 This command displays all registered JDK installations,
-showing their names, paths, and which one is currently active.`,
+showing their names, Java versions (read from each JDK's release file),
+distributions, CPU architectures, runtime kinds (JREs and jlink images
+are marked "no javac"), paths, and which one is currently active.
+
+Use --vendor to only show JDKs of the given distributions
+(temurin, zulu, corretto, graalvm, liberica, semeru, oracle, jbr, ...).
+Use --arch to only show JDKs built for a CPU architecture (amd64, arm64, ...).
+Use --sort version to order by the real Java version instead of the name,
+and --group to print one table per Java feature release, ordered by
+--sort within each table.`,
 	Example: `  jenv list
-jenv ls`,
+jenv ls
+jenv list --vendor oracle
+jenv list --vendor temurin,zulu
+jenv list --arch amd64
+jenv list --sort version
+jenv list --group`,
 	Run: RunList,
 }
 
+var (
+	listVendor string
+	listArch   string
+	listSort   string
+	listGroup  bool
+)
+
 func init() {
 	rootCmd.AddCommand(listCmd)
+	listCmd.Flags().StringVar(&listVendor, "vendor", "", "Only show JDKs from these distributions (comma-separated)")
+	listCmd.Flags().StringVar(&listArch, "arch", "", "Only show JDKs built for this CPU architecture")
+	listCmd.Flags().StringVar(&listSort, "sort", "name", "Sort by name or version")
+	listCmd.Flags().BoolVar(&listGroup, "group", false, "Group JDKs by Java feature release")
 }
 
 func RunList(cmd *cobra.Command, args []string) {
@@ -49,17 +76,62 @@ func RunList(cmd *cobra.Command, args []string) {
 		fmt.Printf("%s: %s\n\n", style.Name.Render("Path"), style.Path.Render(currentJDK.Path))
 	}
 
+	vendors := parseVendorFilter(listVendor)
 	var sorted []config.JDK
 	for _, jdk := range jdks {
+		if len(vendors) > 0 && !vendors[jdk.Vendor] {
+			continue
+		}
+		if listArch != "" && java.NormalizeArch(listArch) != jdk.Arch {
+			continue
+		}
 		sorted = append(sorted, jdk)
 	}
-	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].Name < sorted[j].Name
-	})
+	if len(sorted) == 0 {
+		fmt.Println(style.Path.Render("＞ No JDKs match the given filter"))
+		return
+	}
+	switch listSort {
+	case "name":
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i].Name < sorted[j].Name
+		})
+	case "version":
+		sortByVersion(sorted)
+	default:
+		fmt.Printf("%s: unknown sort key %q (use name or version)\n", style.Error.Render("Error"), listSort)
+		return
+	}
 
+	if !listGroup {
+		renderJDKTable(sorted, currentJDK.Name)
+		return
+	}
+
+	// Group by feature release, oldest first, keeping the --sort order within each group
+	sortByFeature(sorted)
+	var group []config.JDK
+	groupTitle := ""
+	for i, jdk := range sorted {
+		title := featureTitle(jdk)
+		if i > 0 && title != groupTitle {
+			fmt.Println(style.Header.Render(groupTitle))
+			renderJDKTable(group, currentJDK.Name)
+			fmt.Println()
+			group = nil
+		}
+		groupTitle = title
+		group = append(group, jdk)
+	}
+	fmt.Println(style.Header.Render(groupTitle))
+	renderJDKTable(group, currentJDK.Name)
+}
+
+// renderJDKTable prints one table row per JDK, highlighting the current one
+func renderJDKTable(jdks []config.JDK, current string) {
 	// Create and configure table
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Name", "Path", "Current"})
+	table.SetHeader([]string{"Name", "Version", "Vendor", "Arch", "Kind", "Path", "Current"})
 	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
 	table.SetAutoWrapText(false)
 	table.SetAutoFormatHeaders(true)
@@ -68,21 +140,24 @@ func RunList(cmd *cobra.Command, args []string) {
 	table.SetTablePadding(" ")
 	table.SetNoWhiteSpace(true)
 
-	//var[]string data;
 	// Add data rows
-	for _, jdk := range sorted {
+	for _, jdk := range jdks {
 		currentMark := "  "
+		render := style.Path.Render
 		name := style.Name.Render(jdk.Name)
-		path := style.Path.Render(jdk.Path)
-		if jdk.Name == currentJDK.Name {
+		if jdk.Name == current {
 			currentMark = style.Current.Render("✓")
+			render = style.Current.Render
 			name = style.Current.Render(jdk.Name)
-			path = style.Current.Render(jdk.Path)
 		}
 
 		table.Append([]string{
 			name,
-			path,
+			render(displayVersion(jdk)),
+			render(displayVendor(jdk)),
+			render(displayArch(jdk)),
+			render(displayKind(jdk)),
+			render(jdk.Path),
 			currentMark,
 		})
 	}
@@ -90,3 +165,92 @@ func RunList(cmd *cobra.Command, args []string) {
 	// Render the table
 	table.Render()
 }
+
+// sortByVersion orders JDKs by their real Java version; JDKs without a known version go last
+func sortByVersion(jdks []config.JDK) {
+	sort.SliceStable(jdks, func(i, j int) bool {
+		vi, okI := java.JDKVersion(jdks[i])
+		vj, okJ := java.JDKVersion(jdks[j])
+		if okI != okJ {
+			return okI
+		}
+		if c := vi.Compare(vj); okI && c != 0 {
+			return c < 0
+		}
+		return jdks[i].Name < jdks[j].Name
+	})
+}
+
+// sortByFeature orders JDKs by Java feature release only, so that the existing order
+// is kept inside each release; JDKs without a known version go last
+func sortByFeature(jdks []config.JDK) {
+	feature := func(jdk config.JDK) int {
+		if v, ok := java.JDKVersion(jdk); ok {
+			return v.Feature()
+		}
+		return math.MaxInt
+	}
+	sort.SliceStable(jdks, func(i, j int) bool {
+		return feature(jdks[i]) < feature(jdks[j])
+	})
+}
+
+// featureTitle names the group a JDK belongs to, e.g. "Java 17"
+func featureTitle(jdk config.JDK) string {
+	v, ok := java.JDKVersion(jdk)
+	if !ok {
+		return "Unknown version"
+	}
+	return fmt.Sprintf("Java %d", v.Feature())
+}
+
+// displayVersion returns the most specific version recorded for a JDK
+func displayVersion(jdk config.JDK) string {
+	if jdk.JavaRuntimeVersion != "" {
+		return jdk.JavaRuntimeVersion
+	}
+	if jdk.JavaVersion != "" {
+		return jdk.JavaVersion
+	}
+	return "-"
+}
+
+// displayVendor returns the detected distribution of a JDK
+func displayVendor(jdk config.JDK) string {
+	if jdk.Vendor == "" {
+		return "-"
+	}
+	return jdk.Vendor
+}
+
+// displayArch returns the CPU architecture of a JDK, flagging cross-targets
+func displayArch(jdk config.JDK) string {
+	switch {
+	case jdk.Arch == "":
+		return "-"
+	case jdk.CrossTarget:
+		return jdk.Arch + " (cross)"
+	case !java.IsNativeArch(jdk.Arch):
+		return jdk.Arch + " (!)"
+	}
+	return jdk.Arch
+}
+
+// displayKind returns the runtime kind of a JDK, flagging runtimes without javac
+func displayKind(jdk config.JDK) string {
+	if jdk.HasCompiler() {
+		return config.KindJDK
+	}
+	return jdk.Kind + " (no javac)"
+}
+
+// parseVendorFilter turns "temurin,Adoptium" into a set of normalized vendor names
+func parseVendorFilter(value string) map[string]bool {
+	vendors := make(map[string]bool)
+	for _, v := range strings.Split(value, ",") {
+		if v = java.NormalizeVendor(v); v != "" {
+			vendors[v] = true
+		}
+	}
+	return vendors
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/whywhathow/jenv/internal/config"
+	"github.com/whywhathow/jenv/internal/java"
+	"github.com/whywhathow/jenv/internal/project"
+	"github.com/whywhathow/jenv/internal/style"
+)
+
+var (
+	localUnset bool
+	localForce bool
+
+	localCmd = &cobra.Command{
+		Use:   "local [name|selector]",
+		Short: "Pin a JDK for the current directory",
+		Long: `Pin a JDK for the current project by writing a .java-version file.
+
+The file holds a JDK name or selector (see 'jenv use --help') and applies
+to the directory it is in and every directory below it. The JDK in effect
+is decided in this order:
+  1. the JENV_VERSION environment variable
+  2. the nearest version file, from the current directory upwards:
+     .java-version, or the .tool-versions (asdf), .sdkmanrc (SDKMAN!) and
+     .jvmrc (jabba) files of other tools, mapped onto registered JDKs by
+     distribution and version
+  3. the global JDK set by 'jenv use'
+Run 'jenv current --origin' to see which one decided it.
+
+Without an argument the selector pinned for the current directory is shown.`,
+		Example: `  jenv local 17
+  jenv local temurin@21
+  jenv local
+  jenv local --unset`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  runLocal,
+	}
+)
+
+func init() {
+	localCmd.Flags().BoolVar(&localUnset, "unset", false, "Remove the .java-version file in the current directory")
+	localCmd.Flags().BoolVarP(&localForce, "force", "f", false, "Write the file even if no registered JDK matches")
+	rootCmd.AddCommand(localCmd)
+}
+
+func runLocal(cmd *cobra.Command, args []string) {
+	dir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("%s: %s\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
+		return
+	}
+
+	if localUnset {
+		removed, err := project.RemoveVersionFile(dir)
+		switch {
+		case err != nil:
+			fmt.Printf("%s: %s\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
+		case removed:
+			fmt.Printf("%s: %s\n", style.Success.Render("Removed"), style.Path.Render(project.VersionFileName))
+		default:
+			fmt.Println(style.Info.Render("No " + project.VersionFileName + " file in the current directory."))
+		}
+		return
+	}
+
+	if len(args) == 0 {
+		pin, err := project.FindPin(dir)
+		if errors.Is(err, project.ErrNoPin) {
+			fmt.Println(style.Info.Render("No version file found; the global JDK is used."))
+			return
+		}
+		if err != nil {
+			fmt.Printf("%s: %s\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
+			return
+		}
+		fmt.Printf("%s %s\n", style.Current.Render(pin.Selector), style.Info.Render("("+pin.String()+")"))
+		return
+	}
+
+	// 写入前先确认选择器可以解析到已注册的 JDK
+	expr := args[0]
+	res, err := java.ResolveJDK(expr)
+	if err != nil {
+		if !localForce || !errors.Is(err, config.ErrJDKNotFound) {
+			fmt.Printf("%s: %s\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
+			fmt.Println(style.Input.Render("Use --force to pin it anyway."))
+			return
+		}
+		if _, err := java.ParseSelector(expr); err != nil {
+			fmt.Printf("%s: %s\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
+			return
+		}
+		fmt.Printf("%s: %s\n", style.Warning.Render("Warning"), style.Warning.Render(err.Error()))
+	}
+
+	path, err := project.WriteVersionFile(dir, expr)
+	if err != nil {
+		fmt.Printf("%s: %s\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
+		return
+	}
+	fmt.Printf("%s: %s\n", style.Success.Render("Pinned"), style.Path.Render(path))
+	if res.JDK.Name != "" {
+		fmt.Printf("%s: %s\n", style.Info.Render("Resolves to"), style.Current.Render(res.JDK.Name))
+	}
+}
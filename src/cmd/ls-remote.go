@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/whywhathow/jenv/internal/java"
+	"github.com/whywhathow/jenv/internal/provider"
+	"github.com/whywhathow/jenv/internal/style"
+)
+
+var lsRemoteCmd = &cobra.Command{
+	Use:   "ls-remote [selector]",
+	Short: "List JDKs available for 'jenv install'",
+	Long: `List the JDKs the configured provider offers for this machine, newest
+first. A selector narrows the list, e.g. 17, temurin@21 or '>=21'.
+Versions already registered with jenv are marked.
+
+See 'jenv install --help' for the providers and how to configure them.`,
+	Example: `  jenv ls-remote 17
+  jenv ls-remote temurin
+  jenv ls-remote zulu@21 --mirror /mnt/share/jdks`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runLsRemote,
+}
+
+func init() {
+	addProviderFlags(lsRemoteCmd)
+	rootCmd.AddCommand(lsRemoteCmd)
+}
+
+func runLsRemote(cmd *cobra.Command, args []string) {
+	sel := java.Selector{}
+	if len(args) == 1 {
+		var err error
+		if sel, err = java.ParseSelector(args[0]); err != nil {
+			exitWithError(err)
+		}
+	}
+	p, _, err := newProvider()
+	if err != nil {
+		exitWithError(err)
+	}
+	packages, err := p.List(context.Background(), provider.QueryFor(sel))
+	if err != nil {
+		exitWithError(err)
+	}
+
+	// 同一发行版和版本只显示一次
+	seen := make(map[string]bool)
+	var matching []provider.Package
+	for _, pkg := range packages {
+		key := pkg.Vendor + "@" + pkg.Version
+		if seen[key] || !provider.Matches(sel, pkg) {
+			continue
+		}
+		seen[key] = true
+		matching = append(matching, pkg)
+	}
+	if len(matching) == 0 {
+		fmt.Println(style.Path.Render("＞ No JDKs available for this machine match"))
+		return
+	}
+	provider.Sort(matching)
+
+	installed := make(map[string]string)
+	if jdks, err := java.ListJdks(); err == nil {
+		for _, jdk := range jdks {
+			if v, ok := java.JDKVersion(jdk); ok {
+				v.Opt = ""
+				installed[jdk.Vendor+"@"+v.String()] = jdk.Name
+			}
+		}
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Vendor", "Version", "LTS", "File", "Installed"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(true)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetTablePadding(" ")
+	table.SetNoWhiteSpace(true)
+	for _, pkg := range matching {
+		lts := ""
+		if pkg.LTS {
+			lts = "LTS"
+		}
+		mark := ""
+		if v, err := java.ParseVersion(pkg.Version); err == nil {
+			v.Opt = ""
+			if name, ok := installed[pkg.Vendor+"@"+v.String()]; ok {
+				mark = style.Current.Render("✓ " + name)
+			}
+		}
+		table.Append([]string{
+			style.Name.Render(pkg.Vendor),
+			style.Current.Render(pkg.Version),
+			style.Info.Render(lts),
+			style.Path.Render(pkg.Filename),
+			mark,
+		})
+	}
+	table.Render()
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/whywhathow/jenv/internal/java"
+	"github.com/whywhathow/jenv/internal/style"
+)
+
+var rehashCmd = &cobra.Command{
+	Use:   "rehash",
+	Short: "Create shims for the tools of all registered JDKs",
+	Long: `Create shim executables for java, javac, jar, jshell, keytool and every
+other tool found in the bin/ directory of any registered JDK.
+
+A shim picks the JDK for the directory it is run in (JENV_VERSION, the
+nearest .java-version file, then the global JDK) and runs that JDK's tool,
+so terminals in different projects can use different JDKs at the same time.
+Put the shims directory on PATH ahead of $JAVA_HOME/bin to use them.
+
+Once the shims directory exists, jenv refreshes it whenever JDKs are
+added or removed.`,
+	Example: `  jenv rehash`,
+	Args:    cobra.NoArgs,
+	Run:     runRehash,
+}
+
+func init() {
+	rootCmd.AddCommand(rehashCmd)
+}
+
+func runRehash(cmd *cobra.Command, args []string) {
+	result, err := java.Rehash()
+	if err != nil {
+		fmt.Printf("%s: %s\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
+		return
+	}
+
+	fmt.Printf("%s: %d shims in %s\n",
+		style.Success.Render("Rehashed"),
+		len(result.Tools),
+		style.Path.Render(result.Dir))
+	if len(result.Tools) > 0 {
+		fmt.Printf("%s: %s\n", style.Name.Render("Tools"), style.Info.Render(strings.Join(result.Tools, ", ")))
+	}
+	if len(result.Removed) > 0 {
+		fmt.Printf("%s: %s\n", style.Name.Render("Removed"), style.Info.Render(strings.Join(result.Removed, ", ")))
+	}
+
+	if !onPath(result.Dir) {
+		fmt.Println()
+		fmt.Println(style.Warning.Render("The shims directory is not on your PATH yet. Add it in front of $JAVA_HOME/bin:"))
+		if runtime.GOOS == "windows" {
+			fmt.Println(style.Input.Render(fmt.Sprintf(`  setx PATH "%s;%%PATH%%"`, result.Dir)))
+		} else {
+			fmt.Println(style.Input.Render(fmt.Sprintf(`  export PATH="%s:$PATH"`, result.Dir)))
+		}
+	}
+}
+
+// refreshShims regenerates the shims after JDKs were added or removed,
+// but only when the user has opted into shims by running 'jenv rehash'
+func refreshShims() {
+	if !java.ShimsEnabled() {
+		return
+	}
+	if _, err := java.Rehash(); err != nil {
+		fmt.Printf("%s: %s\n", style.Warning.Render("Warning"), style.Warning.Render("failed to refresh shims: "+err.Error()))
+	}
+}
+
+// onPath reports whether dir is an entry of the PATH environment variable
+func onPath(dir string) bool {
+	for _, entry := range filepath.SplitList(os.Getenv("PATH")) {
+		if entry == "" {
+			continue
+		}
+		if filepath.Clean(entry) == filepath.Clean(dir) ||
+			(runtime.GOOS == "windows" && strings.EqualFold(filepath.Clean(entry), filepath.Clean(dir))) {
+			return true
+		}
+	}
+	return false
+}
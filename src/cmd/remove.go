@@ -10,14 +10,17 @@ import (
 
 var removeCmd = &cobra.Command{
 	Aliases: []string{"rm"},
-	Use:     "remove <name>",
+	Use:     "remove <name|selector>",
 	Short:   "Remove a Java JDK",
 	Long: `Remove a Java JDK from the environment.
 
 This command will remove the specified JDK from jenv-go's management.
 It will not delete the actual JDK files from your system.
+A selector (see 'jenv use --help') removes the JDK it resolves to,
+after confirmation.
 
-Use -f or --force flag to skip confirmation prompt.`,
+Use -f or --force flag to skip confirmation prompt. --force requires
+the exact registered name of the JDK.`,
 	Example: ` jenv remove jdk8
 jenv remove -f jdk11
 jenv rm jdk8
@@ -34,27 +37,29 @@ func init() {
 }
 
 func runRemove(cmd *cobra.Command, args []string) {
-	name := args[0]
-
 	// Get JDK info for confirmation
-	jdks, err := java.ListJdks()
+	res, err := java.ResolveJDK(args[0])
 	if err != nil {
-		fmt.Printf("%s: %v\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
+		fmt.Printf("%s: %s\n", style.Error.Render("Error"), style.Error.Render(err.Error()))
 		return
 	}
-
-	jdk, exists := jdks[name]
-	if !exists {
-		fmt.Printf("%s: %s\n", style.Error.Render("Error"), style.Error.Render("JDK not found"))
-		return
+	jdk := res.JDK
+	name := jdk.Name
+	if res.Selector.Raw != "" {
+		fmt.Printf("%s: %s\n", style.Info.Render("Resolved"), style.Info.Render(res.Reason))
+		// 选择器可能匹配到用户没有直接指定的 JDK，不能跳过确认
+		if force {
+			fmt.Printf("%s: %s\n", style.Error.Render("Error"),
+				style.Error.Render(fmt.Sprintf("--force requires an exact JDK name; %q is a selector that resolved to %s", args[0], name)))
+			return
+		}
 	}
 
 	// Show JDK info and confirm removal
+	fmt.Println(style.Header.Render("\nRemoving JDK"))
+	fmt.Printf("%s: %s\n", style.Name.Render("Name"), style.Current.Render(jdk.Name))
+	fmt.Printf("%s: %s\n\n", style.Name.Render("Path"), style.Path.Render(jdk.Path))
 	if !force {
-		fmt.Println(style.Header.Render("\nRemoving JDK"))
-		fmt.Printf("%s: %s\n", style.Name.Render("Name"), style.Current.Render(jdk.Name))
-		fmt.Printf("%s: %s\n\n", style.Name.Render("Path"), style.Path.Render(jdk.Path))
-
 		fmt.Print(style.Input.Render("Are you sure you want to remove this JDK? [y/N] "))
 		var confirm string
 		fmt.Scanln(&confirm)
@@ -72,5 +77,6 @@ func runRemove(cmd *cobra.Command, args []string) {
 	}
 
 	fmt.Printf("%s: %s\n", style.Success.Render("Successfully removed JDK"), style.Name.Render(name))
+	refreshShims()
 	return
 }
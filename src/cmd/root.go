@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/whywhathow/jenv/internal/config"
+	"github.com/whywhathow/jenv/internal/java"
 	"github.com/whywhathow/jenv/internal/style"
 	"github.com/whywhathow/jenv/internal/sys"
 )
@@ -26,7 +27,8 @@ First time setup:
 ➜ Run 'jenv add-to-path' to add jenv to your system PATH
 ➜ Run 'jenv scan <dir>' to find and add Java installations
 ➜ Run 'jenv use <name>' to select a Java version`,
-	Version: Version,
+	Version:          Version,
+	PersistentPreRun: preRun,
 }
 
 func init() {
@@ -35,7 +37,12 @@ Author: WhyWhatHow (https://github.com/WhyWhatHow)
 Email: whywhathow.fun@gmail.com
 License: Apache License 2.0
 `)
+}
 
+// preRun performs the privilege and configuration checks shared by all commands.
+// It runs before the command instead of in init() so that commands such as the
+// shims can skip it and start quickly.
+func preRun(cmd *cobra.Command, args []string) {
 	// Check admin privileges - different logic for different platforms
 	if runtime.GOOS == "windows" {
 		// Windows always requires administrator privileges
@@ -74,6 +81,9 @@ License: Apache License 2.0
 		}
 	}
 
+	// 为旧版本注册的JDK补充 release 元数据
+	java.RefreshMetadata()
+
 	// Check if jenv has been initialized
 	if !cfg.Initialized {
 		fmt.Printf("%s: %s\n",
@@ -2,17 +2,22 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	"github.com/whywhathow/jenv/internal/java"
 	"github.com/whywhathow/jenv/internal/style"
+	"github.com/whywhathow/jenv/internal/sys"
 )
 
 var (
 	scanCmd = &cobra.Command{
 		Aliases: []string{"sc"},
-		Use:     "scan <dir>",
+		Use:     "scan <dir> | --auto | --include-tooling [dir] | --explain <path>",
 		Short:   "Scan a directory for JDKs (max depth: 5 subdirectories)",
 		Long: `Scan a specified directory for JDK installations and add them to jenv's config.
 
@@ -29,37 +34,172 @@ For example, if scanning from "C:":
 
 This command will:
 1. Search for JDKs in the specified directory and its subdirectories
-2. Skip system directories (Windows, $Recycle.Bin, System Volume Information)
-3. Add the JDKs to jenv's configuration`,
+2. Skip directories excluded by the scan rules (see below)
+3. Add the JDKs to jenv's configuration
+
+Only full JDKs (with bin/javac) are found by default. Use --include-jre
+to also find JREs and jlink runtime images.
+
+General scans skip the directories of build tools and IDEs. Use
+--include-tooling to also look in the places where tools keep runtimes
+they provisioned or bundle, with or without a directory to scan:
+  gradle          Gradle toolchains (~/.gradle/jdks)
+  jetbrains       the JetBrains Runtime (jbr) of installed IDEs
+  android-studio  Android Studio's bundled jbr
+  coursier        Coursier's JVM cache
+Each runtime found there is tagged with the tool it came from.
+
+Use --auto to scan the usual JDK locations of this system instead of
+guessing them, e.g. /usr/lib/jvm, /usr/java and /opt on Linux,
+/Library/Java/JavaVirtualMachines and Homebrew on macOS, the vendor
+folders under Program Files on Windows, and ~/.jdks, ~/.sdkman, ~/.asdf
+and ~/.jabba everywhere. The locations are scanned concurrently; the
+statistics of each are shown and JDKs found more than once are listed once.
+
+By default a name is asked for every JDK found. With --yes every JDK is
+added under a name made from --name, a template over the JDK's release
+file (default {{vendor}}-{{major}}, e.g. temurin-17). Placeholders:
+  {{vendor}} {{major}} {{version}} {{full}} {{arch}} {{kind}}
+  {{implementor}} {{source}} {{dir}}
+A name that is already taken gets a suffix: temurin-17-2, temurin-17-3.
+A name that would be read as a selector, such as a bare 17 when the
+vendor is unknown, is replaced by the directory name.
+--dry-run lists the names without adding anything. When standard input
+is not a terminal, e.g. in scripts, --yes is implied.
+
+Which directories are entered is decided by gitignore-style rules. By
+default system folders, build output (bin, build, out, target, ...), IDE
+and package manager folders and names containing temp, cache or backup
+are skipped. In config.json, scan.exclude replaces these defaults and
+scan.include lists directories to scan anyway:
+  "scan": {"include": ["/opt/build-tools", "jdk-*"]}
+--exclude and --include add rules for one scan. A pattern without a slash
+matches a directory name, one with a slash the end of a path, or the
+whole path when it starts with '/'. '*' matches within a name, '**' any
+number of directories, and a leading '!' negates a rule. The last
+matching rule wins and case is ignored. --explain <path> shows which rule
+decides whether a path is scanned.`,
 
 		Example: `  jenv scan C:\\
   jenv scan "C:\\Program Files\\Java"
   jenv scan C:\\Users\\Username\\.jdks
-  jenv sc  C:\\Program Files\\Java `,
-		Args: cobra.ExactArgs(1),
-		Run:  runScan,
+  jenv sc  C:\\Program Files\\Java
+  jenv scan --include-jre /usr/lib/jvm
+  jenv scan --include-tooling
+  jenv scan --auto
+  jenv scan --auto --yes --name '{{vendor}}-{{major}}'
+  jenv scan /usr/lib/jvm --dry-run
+  jenv scan /opt --include build-tools --exclude 'android-*'
+  jenv scan --explain /opt/build-tools/jdk17`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if scanExplain != "" {
+				return cobra.NoArgs(cmd, args)
+			}
+			if scanAuto || scanIncludeTooling {
+				return cobra.MaximumNArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		Run: runScan,
 	}
 )
 
+var (
+	scanIncludeJRE     bool
+	scanIncludeTooling bool
+	scanAuto           bool
+	scanYes            bool
+	scanDryRun         bool
+	scanNameTemplate   string
+	scanExclude        []string
+	scanInclude        []string
+	scanExplain        string
+)
+
 func init() {
 	rootCmd.AddCommand(scanCmd)
+	scanCmd.Flags().BoolVar(&scanIncludeJRE, "include-jre", false, "Also find JREs and jlink runtime images without javac")
+	scanCmd.Flags().BoolVarP(&scanYes, "yes", "y", false, "Add every JDK found without prompting, named by --name")
+	scanCmd.Flags().StringVar(&scanNameTemplate, "name", "", "Name template for --yes, e.g. '{{vendor}}-{{major}}' (default \""+java.DefaultNameTemplate+"\")")
+	scanCmd.Flags().BoolVar(&scanDryRun, "dry-run", false, "Only list the JDKs and names that would be added")
+	scanCmd.Flags().BoolVar(&scanAuto, "auto", false, "Scan the well-known JDK locations of this system")
+	scanCmd.Flags().StringArrayVar(&scanExclude, "exclude", nil, "Skip directories matching this pattern (repeatable)")
+	scanCmd.Flags().StringArrayVar(&scanInclude, "include", nil, "Scan directories matching this pattern even if excluded (repeatable)")
+	scanCmd.Flags().StringVar(&scanExplain, "explain", "", "Show which scan rule decides whether `path` is scanned")
+	scanCmd.Flags().BoolVar(&scanIncludeTooling, "include-tooling", false, "Also look for runtimes provisioned by Gradle, JetBrains IDEs, Android Studio and Coursier")
 }
 
 func runScan(cmd *cobra.Command, args []string) {
-	dir := args[0]
+	rules, err := java.LoadScanRules(scanExclude, scanInclude)
+	if err != nil {
+		exitWithError(err)
+	}
+	if scanExplain != "" {
+		explainScanRules(rules, scanExplain)
+		return
+	}
+
+	// 不提示输入名称的批量模式；标准输入不是终端时（脚本、Ansible）自动启用
+	batch := scanYes || scanDryRun || scanNameTemplate != ""
+	if !batch && !sys.IsTerminal(os.Stdin.Fd()) {
+		batch = true
+		fmt.Println(style.Info.Render("Standard input is not a terminal; naming JDKs with " + java.DefaultNameTemplate))
+	}
+	var tmpl java.NameTemplate
+	if batch {
+		raw := scanNameTemplate
+		if raw == "" {
+			raw = java.DefaultNameTemplate
+		}
+		if tmpl, err = java.ParseNameTemplate(raw); err != nil {
+			exitWithError(err)
+		}
+	}
 
 	// 显示扫描标题
-	header := style.Header.Render("🔍 Scanning directory: ") + style.Path.Render(dir)
+	var roots []string
+	if len(args) == 1 {
+		roots = append(roots, args[0])
+	}
+	if scanAuto {
+		roots = append(roots, java.AutoScanRoots()...)
+	}
+
+	var header string
+	switch {
+	case scanAuto:
+		header = style.Header.Render("🔍 Scanning well-known locations: ") + style.Path.Render(fmt.Sprintf("%d directories", len(roots)))
+	case len(roots) == 1:
+		header = style.Header.Render("🔍 Scanning directory: ") + style.Path.Render(roots[0])
+	}
+	if scanIncludeTooling {
+		if header != "" {
+			header += "\n"
+		}
+		header += style.Header.Render("🔍 Scanning tooling locations: ") + style.Path.Render("gradle, jetbrains, android-studio, coursier")
+	}
 	fmt.Println(header + "\n" + strings.Repeat("─", 50))
 
 	// Show scanning progress message
 	fmt.Println(style.Input.Render("⏳ Scanning for JDK installations..."))
 	fmt.Println(style.Input.Render("   • Excluding already registered JDKs"))
-	fmt.Println(style.Input.Render("   • Skipping system directories"))
+	fmt.Println(style.Input.Render("   • Skipping directories excluded by scan rules"))
 	fmt.Println()
 
 	// Use the new optimized scan with statistics
-	result := java.ScanJDKWithStats(dir)
+	opts := java.ScanOptions{IncludeJRE: scanIncludeJRE, Rules: rules}
+	var result java.ScanResult
+	switch {
+	case scanAuto:
+		var scans []java.RootScan
+		scans, result = java.ScanRoots(roots, opts)
+		renderRootScans(scans)
+	case len(roots) == 1:
+		result = java.ScanJDKWithOptions(roots[0], opts)
+	}
+	if scanIncludeTooling {
+		result = java.MergeScanResults(result, java.ScanTooling())
+	}
 
 	// Display scan statistics
 	fmt.Printf("%s\n", style.Header.Render("📊 Scan Results"))
@@ -80,19 +220,40 @@ func runScan(cmd *cobra.Command, args []string) {
 
 	successCount := 0
 	skipCount := 0
+	taken := make(map[string]bool)
+	if jdks, err := java.ListJdks(); err == nil {
+		for name := range jdks {
+			taken[name] = true
+		}
+	}
 
 	for i, jdk := range result.JDKs {
 		// 显示带编号的JDK发现信息
-		fmt.Printf("\n%s %s\n",
+		tag := jdk.Kind
+		if jdk.Source != "" {
+			tag += ", " + jdk.Source
+		}
+		fmt.Printf("\n%s %s %s\n",
 			style.Name.Render(fmt.Sprintf("#%02d", i+1)),
-			style.Path.Render(jdk.Path))
-
-		// 带样式的输入提示
-		prompt := style.Input.Render("⇨ Enter a name for this JDK (e.g. jdk11, jdk21-azul): ")
-		fmt.Print(prompt + " ")
+			style.Path.Render(jdk.Path),
+			style.Info.Render("("+tag+")"))
 
 		var name string
-		fmt.Scanln(&name)
+		if batch {
+			// 名称由模板生成，与已注册和本次已分配的名称冲突时加数字后缀
+			name = java.UniqueName(tmpl.Render(jdk), func(n string) bool { return taken[n] })
+			taken[name] = true
+			if scanDryRun {
+				fmt.Printf("%s: %s\n", style.Info.Render("• Would add JDK"), style.Name.Render(name))
+				successCount++
+				continue
+			}
+		} else {
+			// 带样式的输入提示
+			prompt := style.Input.Render("⇨ Enter a name for this JDK (e.g. jdk11, jdk21-azul): ")
+			fmt.Print(prompt + " ")
+			fmt.Scanln(&name)
+		}
 
 		if name == "" {
 			fmt.Println(style.Input.Render("↪ Skipping unnamed JDK"))
@@ -100,7 +261,13 @@ func runScan(cmd *cobra.Command, args []string) {
 			continue
 		}
 
-		if err := java.AddJDK(name, jdk.Path); err != nil {
+		var err error
+		if batch {
+			name, err = java.AddJDKUnique(name, jdk.Path, java.AddOptions{Kind: jdk.Kind, Source: jdk.Source})
+		} else {
+			err = java.AddJDKWithOptions(name, jdk.Path, java.AddOptions{Kind: jdk.Kind, Source: jdk.Source})
+		}
+		if err != nil {
 			fmt.Printf("%s: %v\n",
 				style.Error.Render("✖ Failed to add JDK"),
 				style.Error.Render(err.Error()))
@@ -114,12 +281,85 @@ func runScan(cmd *cobra.Command, args []string) {
 	}
 
 	// 显示最终统计信息
+	added := "Successfully Added"
+	if scanDryRun {
+		added = "Would Add"
+	}
 	summary := fmt.Sprintf("\n%s\n%s: %d\n%s: %d\n%s: %d\n%s: %s",
 		style.Header.Render("✅ Scan Complete!"),
 		style.Name.Render("New JDKs Found"), len(result.JDKs),
-		style.Success.Render("Successfully Added"), successCount,
+		style.Success.Render(added), successCount,
 		style.Error.Render("Skipped"), skipCount,
 		style.Name.Render("Total Time"), style.Success.Render(result.Duration.String()))
 
 	fmt.Println(summary)
+	if successCount > 0 && !scanDryRun {
+		refreshShims()
+	}
+}
+
+// renderRootScans prints the statistics of each scanned root
+func renderRootScans(scans []java.RootScan) {
+	fmt.Printf("%s\n", style.Header.Render("📂 Locations"))
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Directory", "Scanned", "Skipped", "Excluded", "Found", "Duration"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(true)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetTablePadding(" ")
+	table.SetNoWhiteSpace(true)
+	for _, scan := range scans {
+		found := style.Path.Render("0")
+		if n := len(scan.Result.JDKs); n > 0 {
+			found = style.Success.Render(strconv.Itoa(n))
+		}
+		table.Append([]string{
+			style.Path.Render(scan.Root),
+			strconv.Itoa(scan.Result.Scanned),
+			strconv.Itoa(scan.Result.Skipped),
+			strconv.Itoa(scan.Result.Excluded),
+			found,
+			scan.Result.Duration.String(),
+		})
+	}
+	table.Render()
+	fmt.Println()
+}
+
+// explainScanRules prints whether a scan enters path and which rules decide it
+func explainScanRules(rules java.ScanRules, path string) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	matches := rules.Explain(abs)
+	switch {
+	case len(matches) == 0 || matches[0].Dir != abs:
+		fmt.Printf("%s %s\n", style.Success.Render("✔ Scanned:"), style.Path.Render(abs))
+		fmt.Println(style.Input.Render("  no scan rule matches"))
+	case matches[0].Rule.Include:
+		fmt.Printf("%s %s\n", style.Success.Render("✔ Scanned:"), style.Path.Render(abs))
+		fmt.Printf("  %s %s\n", style.Input.Render("matches include rule"), describeScanRule(matches[0].Rule))
+		matches = matches[1:]
+	default:
+		fmt.Printf("%s %s\n", style.Error.Render("✖ Not scanned:"), style.Path.Render(abs))
+		fmt.Printf("  %s %s\n", style.Input.Render("matches exclude rule"), describeScanRule(matches[0].Rule))
+		matches = matches[1:]
+	}
+
+	// 被排除的上级目录只影响从它或更上层开始的扫描
+	for _, m := range matches {
+		if m.Rule.Include {
+			continue
+		}
+		fmt.Printf("%s %s %s %s\n",
+			style.Input.Render("  ⚠ Scans starting at or above"), style.Path.Render(m.Dir),
+			style.Input.Render("skip it, exclude rule"), describeScanRule(m.Rule))
+	}
+}
+
+func describeScanRule(rule java.ScanRule) string {
+	return style.Name.Render(strconv.Quote(rule.Pattern)) + " " + style.Info.Render("("+rule.Source+")")
 }
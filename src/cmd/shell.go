@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/whywhathow/jenv/internal/java"
+	"github.com/whywhathow/jenv/internal/shell"
+)
+
+var (
+	shellUnset bool
+	shellName  string
+
+	shellCmd = &cobra.Command{
+		Use:   "shell [name|selector]",
+		Short: "Switch the JDK for the current shell session only",
+		Long: `Print shell code that switches the JDK for the current terminal only.
+
+The code sets JAVA_HOME, puts the JDK's bin directory first on PATH and sets
+JENV_VERSION, which also takes precedence over .java-version files for shims.
+Other terminals and the global JDK set by 'jenv use' are not affected.
+'jenv shell --unset' restores the values the session had before.
+
+The output has to be evaluated by your shell:
+  bash, zsh, sh   eval "$(jenv shell 17)"
+  fish            jenv shell 17 | source
+  PowerShell      jenv shell 17 | Out-String | Invoke-Expression
+
+The shell is detected from $SHELL (PowerShell on Windows); use --shell to
+choose it explicitly. Without an argument the session's JDK is printed.`,
+		Example: `  eval "$(jenv shell 17)"
+  eval "$(jenv shell --unset)"
+  jenv shell temurin@21 --shell fish | source
+  jenv shell 11 --shell pwsh | Out-String | Invoke-Expression`,
+		Args:             cobra.MaximumNArgs(1),
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+		Run:              runShell,
+	}
+)
+
+func init() {
+	shellCmd.Flags().BoolVar(&shellUnset, "unset", false, "Restore JAVA_HOME and PATH from before 'jenv shell'")
+	shellCmd.Flags().StringVar(&shellName, "shell", "", "Shell to generate code for: bash, zsh, fish, sh or pwsh")
+	rootCmd.AddCommand(shellCmd)
+}
+
+// runShell writes shell code to stdout and everything meant for the user to stderr,
+// so that the output can be evaluated as is
+func runShell(cmd *cobra.Command, args []string) {
+	name := shellName
+	if name == "" {
+		name = string(shell.DetectSessionShell())
+	}
+	sh, err := shell.GetSessionShellConfig(name)
+	if err != nil {
+		shellFail(err)
+	}
+
+	if shellUnset {
+		script, ok := shell.DeactivateScript(sh, os.LookupEnv)
+		if !ok {
+			shellFail(fmt.Errorf("no 'jenv shell' session is active"))
+		}
+		fmt.Print(script)
+		return
+	}
+
+	if len(args) == 0 {
+		version, ok := os.LookupEnv(java.EnvVersion)
+		if !ok {
+			shellFail(fmt.Errorf("no shell-specific JDK is configured"))
+		}
+		fmt.Println(version)
+		return
+	}
+
+	res, err := java.ResolveJDK(args[0])
+	if err != nil {
+		shellFail(err)
+	}
+	warning, err := java.CheckArch(res.JDK)
+	if err != nil {
+		shellFail(err)
+	}
+	if warning != "" {
+		fmt.Fprintf(os.Stderr, "jenv: warning: %s\n", warning)
+	}
+
+	fmt.Print(shell.ActivateScript(sh, java.JDKVars(os.Environ(), res.JDK), os.LookupEnv))
+}
+
+func shellFail(err error) {
+	fmt.Fprintf(os.Stderr, "jenv shell: %v\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/whywhathow/jenv/internal/java"
+	"github.com/whywhathow/jenv/internal/sys"
+)
+
+// shimCmd is what the scripts written by 'jenv rehash' call. It must stay fast:
+// it skips the root checks, never writes the config and prints only to stderr.
+var shimCmd = &cobra.Command{
+	Use:                "shim <tool> [args...]",
+	Short:              "Run a JDK tool with the JDK in effect for the current directory",
+	Hidden:             true,
+	DisableFlagParsing: true,
+	Args:               cobra.MinimumNArgs(1),
+	PersistentPreRun:   func(cmd *cobra.Command, args []string) {},
+	Run:                runShim,
+}
+
+func init() {
+	rootCmd.AddCommand(shimCmd)
+}
+
+func runShim(cmd *cobra.Command, args []string) {
+	tool := args[0]
+	dir, err := os.Getwd()
+	if err != nil {
+		shimFail(tool, err, 1)
+	}
+
+	path, active, err := java.ResolveTool(tool, dir, os.Getenv)
+	if err != nil {
+		code := 1
+		if errors.Is(err, java.ErrToolNotFound) {
+			code = 127
+		}
+		shimFail(tool, err, code)
+	}
+
+	argv := append([]string{path}, args[1:]...)
+	if err := sys.Exec(path, argv, java.ToolEnv(os.Environ(), active.JDK.Path)); err != nil {
+		shimFail(tool, err, 126)
+	}
+}
+
+func shimFail(tool string, err error, code int) {
+	fmt.Fprintf(os.Stderr, "jenv: %s: %v\n", tool, err)
+	os.Exit(code)
+}
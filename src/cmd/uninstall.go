@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/whywhathow/jenv/internal/config"
+	"github.com/whywhathow/jenv/internal/install"
+	"github.com/whywhathow/jenv/internal/java"
+	"github.com/whywhathow/jenv/internal/style"
+)
+
+var (
+	uninstallForce    bool
+	uninstallFallback string
+
+	uninstallCmd = &cobra.Command{
+		Use:   "uninstall <name>",
+		Short: "Delete a JDK installed by jenv",
+		Long: `Delete the files of a JDK and remove it from jenv.
+
+Only JDKs inside a directory jenv owns are deleted: the store that
+'jenv install' uses (~/.jdks/store), or the directories listed in
+install.owned_roots in config.json. The JDK's real path must be inside
+the same directory, so a symbolic link cannot lead the deletion out of it.
+JDKs elsewhere are left alone; use 'jenv remove' to unregister them.
+
+The JDK must be given by its exact registered name; selectors such as
+corretto@17 are not accepted, so a deletion never picks a JDK you did
+not name. The name, path and directory to delete are always shown.
+
+The current JDK is not uninstalled unless --fallback names a JDK to
+switch to first.
+
+Use -f or --force flag to skip confirmation prompt.`,
+		Example: `  jenv uninstall temurin-17.0.9+9
+  jenv uninstall corretto-17.0.9.8.1 -f
+  jenv uninstall temurin-21.0.1+12 --fallback temurin@21`,
+		Args: cobra.ExactArgs(1),
+		Run:  runUninstall,
+	}
+)
+
+func init() {
+	uninstallCmd.Flags().BoolVarP(&uninstallForce, "force", "f", false, "Skip confirmation prompt")
+	uninstallCmd.Flags().StringVar(&uninstallFallback, "fallback", "", "JDK to switch to when uninstalling the current one")
+	rootCmd.AddCommand(uninstallCmd)
+}
+
+func runUninstall(cmd *cobra.Command, args []string) {
+	name := args[0]
+	opts := install.UninstallOptions{Fallback: uninstallFallback}
+	jdk, dir, err := install.UninstallTarget(name, opts)
+	if errors.Is(err, config.ErrJDKNotFound) {
+		// 删除文件只接受准确的名称，选择器只用来提示
+		if res, rerr := java.ResolveJDK(name); rerr == nil {
+			err = fmt.Errorf("%w: uninstall needs the exact name of a registered JDK; %q resolves to %s", err, name, res.JDK.Name)
+		}
+	}
+	if err != nil {
+		uninstallFailed(err)
+	}
+
+	fmt.Println(style.Header.Render("\nUninstalling JDK"))
+	fmt.Printf("%s: %s\n", style.Name.Render("Name"), style.Current.Render(jdk.Name))
+	fmt.Printf("%s: %s\n", style.Name.Render("Path"), style.Path.Render(jdk.Path))
+	fmt.Printf("%s: %s\n\n", style.Name.Render("Delete"), style.Path.Render(dir))
+
+	if !uninstallForce {
+		fmt.Print(style.Input.Render("Delete this directory from disk? [y/N] "))
+		var confirm string
+		fmt.Scanln(&confirm)
+
+		if confirm != "y" && confirm != "Y" {
+			fmt.Println(style.Input.Render("\nOperation cancelled"))
+			return
+		}
+	}
+
+	result, err := install.Uninstall(jdk.Name, opts)
+	if result.Fallback != "" {
+		fmt.Printf("%s: %s\n", style.Success.Render("Switched to"), style.Name.Render(result.Fallback))
+	}
+	if err != nil {
+		uninstallFailed(err)
+	}
+
+	fmt.Printf("%s: %s\n", style.Success.Render("Successfully uninstalled JDK"), style.Name.Render(result.Name))
+	fmt.Printf("%s: %s %s\n", style.Name.Render("Reclaimed"), style.Current.Render(formatBytes(result.Reclaimed)), style.Path.Render("("+result.Dir+")"))
+	refreshShims()
+}
+
+// uninstallFailed prints err with a hint for the errors the user can act on and exits
+func uninstallFailed(err error) {
+	fmt.Printf("%s: %s\n", style.Error.Render("Failed to uninstall JDK"), style.Error.Render(err.Error()))
+	switch {
+	case errors.Is(err, install.ErrNotOwned):
+		fmt.Println(style.Info.Render("Use 'jenv remove' to unregister it without deleting files"))
+	case errors.Is(err, install.ErrCurrentJDK):
+		fmt.Println(style.Info.Render("Use --fallback <name> to switch to another JDK first"))
+	}
+	os.Exit(1)
+}
+
+// formatBytes renders a size with a binary unit, e.g. 312.4 MiB
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
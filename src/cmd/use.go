@@ -3,30 +3,82 @@ package cmd
 import (
 	"fmt"
 	"github.com/whywhathow/jenv/internal/java"
+	"github.com/whywhathow/jenv/internal/style"
 
 	"github.com/spf13/cobra"
 )
 
 var (
+	useDetect bool
+
 	useCmd = &cobra.Command{
-		Use:   "use <name>",
+		Use:   "use <name|selector>",
 		Short: "Switch to a different Java JDK",
 		Long: `Switch to a different Java JDK version.
 
 This command will set the specified JDK as the current Java version
-for your environment.`,
-		Example: "  jenv use jdk8",
-		Args:    cobra.ExactArgs(1),
-		Run:     RunUse,
+for your environment.
+
+Instead of a registered name you can pass a selector:
+  17, 17.0.9, 1.8      a version prefix
+  '>=11 <17'           version constraints, all must match
+  temurin@21           a distribution and version
+  latest, latest-lts   the newest (LTS) JDK
+When several JDKs match, JDKs built for this machine win first, then
+JDKs whose version is known, then GA builds over early access builds,
+then the highest version, then the alphabetically first name.
+
+With --detect the version is read from the project's build files instead
+(see 'jenv detect --help').`,
+		Example: `  jenv use jdk8
+  jenv use 17
+  jenv use '>=11 <17'
+  jenv use temurin@21
+  jenv use latest-lts
+  jenv use --detect`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if useDetect {
+				if len(args) > 0 {
+					return fmt.Errorf("--detect does not take a JDK name or selector")
+				}
+				return nil
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		Run: RunUse,
 	}
 )
 
 func init() {
+	useCmd.Flags().BoolVar(&useDetect, "detect", false, "Pick the JDK the build files of the current project require")
 	rootCmd.AddCommand(useCmd)
 }
 
 func RunUse(cmd *cobra.Command, args []string) {
-	name := args[0]
+	// Resolve the name or selector first so the choice can be explained
+	var res java.Resolution
+	var err error
+	if useDetect {
+		req, ok := detectRequirement(".")
+		if !ok {
+			return
+		}
+		fmt.Printf("%s: Java %d (%s)\n", style.Info.Render("Detected"), req.Level, req.String())
+		res, err = java.ResolveRequirement(req)
+	} else {
+		res, err = java.ResolveJDK(args[0])
+	}
+	if err != nil {
+		fmt.Printf("failed to switch JDK: %v\n", err)
+		return
+	}
+	name := res.JDK.Name
+	if res.Selector.Raw != "" {
+		fmt.Printf("%s: %s\n", style.Info.Render("Resolved"), style.Info.Render(res.Reason))
+	}
+	if warning, _ := java.CheckArch(res.JDK); warning != "" {
+		fmt.Printf("%s: %s\n", style.Warning.Render("Warning"), style.Warning.Render(warning))
+	}
 
 	// Switch JDK
 	if err := java.UseJDK(name); err != nil {
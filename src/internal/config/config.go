@@ -32,13 +32,78 @@ type Config struct {
 	EnvBackUpPath string         `json:"env_backup_path"`
 	Jdks          map[string]JDK `json:"jdks"`  // 将数组改为 map
 	Theme         string         `json:"theme"` // Current theme name
+	// Install 为 jenv install 和 ls-remote 下载 JDK 的来源设置
+	Install InstallSettings `json:"install"`
+	// Verify 列出签署 JDK 压缩包的可信公钥
+	Verify VerifySettings `json:"verify"`
+	// Scan 为 jenv scan 跳过或进入目录的规则
+	Scan ScanSettings `json:"scan"`
 	// 添加互斥锁保护并发访问
 	lock sync.RWMutex
 }
 
+// InstallSettings configures where 'jenv install' and 'jenv ls-remote' find JDKs
+type InstallSettings struct {
+	Provider  string `json:"provider,omitempty"`   // foojay（默认）或 mirror
+	FoojayURL string `json:"foojay_url,omitempty"` // Foojay Disco API 地址，可指向内部代理
+	MirrorURL string `json:"mirror_url,omitempty"` // 镜像目录或 HTTP 地址，其中包含 catalog.json
+	Proxy     string `json:"proxy,omitempty"`      // HTTP 代理，为空时使用 HTTPS_PROXY 等环境变量
+	// OwnedRoots 为 jenv 管理的目录，jenv uninstall 只删除其中的 JDK，默认 ~/.jdks/store
+	OwnedRoots []string `json:"owned_roots,omitempty"`
+}
+
+// VerifySettings lists the keys trusted to sign JDK archives, see 'jenv verify-archive'
+type VerifySettings struct {
+	MinisignKeys     []string `json:"minisign_keys,omitempty"`     // minisign 公钥（.pub 文件第二行）或 .pub 文件路径
+	GPGKeyrings      []string `json:"gpg_keyrings,omitempty"`      // 供 gpgv 使用的公钥环文件
+	RequireSignature bool     `json:"require_signature,omitempty"` // 为 true 时拒绝没有可信签名的压缩包
+}
+
+// ScanSettings holds the gitignore-style rules deciding which directories 'jenv scan' enters
+type ScanSettings struct {
+	Exclude []string `json:"exclude,omitempty"` // 跳过的目录，为空时使用内置规则；以 '!' 开头表示重新包含
+	Include []string `json:"include,omitempty"` // 即使被 exclude 匹配也要扫描的目录
+}
+
 type JDK struct {
 	Name string `json:"name"`
 	Path string `json:"path"`
+	// 以下字段来自 JDK 目录下的 release 文件
+	JavaVersion        string   `json:"java_version,omitempty"`
+	JavaRuntimeVersion string   `json:"java_runtime_version,omitempty"`
+	Implementor        string   `json:"implementor,omitempty"`
+	ImplementorVersion string   `json:"implementor_version,omitempty"`
+	OSArch             string   `json:"os_arch,omitempty"`
+	Modules            []string `json:"modules,omitempty"`
+	// Vendor 为识别出的发行版，如 temurin、zulu、oracle
+	Vendor string `json:"vendor,omitempty"`
+	// Arch 为 bin/java 的 CPU 架构（GOARCH 命名）
+	Arch string `json:"arch,omitempty"`
+	// CrossTarget 标记用户明确登记的非本机架构 JDK（如 jlink 交叉编译目标）
+	CrossTarget bool `json:"cross_target,omitempty"`
+	// Kind 为运行时类型：jdk、jre 或 custom-image，空值视为 jdk
+	Kind string `json:"kind,omitempty"`
+	// Source 记录由哪个工具提供，如 gradle、jetbrains，扫描到的工具目录之外为空
+	Source string `json:"source,omitempty"`
+	// MetadataProbed 表示已经检测过上述元数据，没有 release 文件等检测失败的条目不再重复检测
+	MetadataProbed bool `json:"metadata_probed,omitempty"`
+}
+
+// 运行时类型
+const (
+	KindJDK         = "jdk"
+	KindJRE         = "jre"
+	KindCustomImage = "custom-image"
+)
+
+// HasCompiler reports whether the runtime ships javac
+func (j JDK) HasCompiler() bool {
+	return j.Kind == "" || j.Kind == KindJDK
+}
+
+// HasMetadata reports whether release metadata has been recorded for the JDK
+func (j JDK) HasMetadata() bool {
+	return j.JavaVersion != "" || j.JavaRuntimeVersion != "" || j.Implementor != ""
 }
 
 // GetInstance 返回配置的单例实例
@@ -162,8 +227,16 @@ func (c *Config) SetCurrentJDK(jdkName string) error {
 
 // AddJDK 添加新的JDK
 func (c *Config) AddJDK(name, path string) error {
-	// 验证Java路径
-	if !ValidateJavaPath(path) {
+	return c.RegisterJDK(JDK{Name: name, Path: path})
+}
+
+// RegisterJDK 添加新的JDK，保留调用方提供的元数据
+func (c *Config) RegisterJDK(jdk JDK) error {
+	// 验证Java路径，JRE 和 jlink 镜像只需要 bin/java
+	if jdk.HasCompiler() && !ValidateJavaPath(jdk.Path) {
+		return ErrInvalidPath
+	}
+	if !jdk.HasCompiler() && !ValidateJavaRuntimePath(jdk.Path) {
 		return ErrInvalidPath
 	}
 
@@ -171,17 +244,45 @@ func (c *Config) AddJDK(name, path string) error {
 	defer c.lock.Unlock()
 
 	// 检查是否已存在同名JDK
-	if _, exists := c.Jdks[name]; exists {
+	if _, exists := c.Jdks[jdk.Name]; exists {
 		return ErrJDKExists
 	}
 
 	// 添加新JDK
-	c.Jdks[name] = JDK{Name: name, Path: path}
+	c.Jdks[jdk.Name] = jdk
 
 	// 保存更新后的配置到文件
 	return c.doSave()
 }
 
+// JDKList 返回已注册JDK的副本，读取时持有锁
+func (c *Config) JDKList() []JDK {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	jdks := make([]JDK, 0, len(c.Jdks))
+	for _, jdk := range c.Jdks {
+		jdks = append(jdks, jdk)
+	}
+	return jdks
+}
+
+// UpdateJDK 更新已注册JDK的信息，只保存一次
+func (c *Config) UpdateJDK(jdks ...JDK) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, jdk := range jdks {
+		if _, exists := c.Jdks[jdk.Name]; !exists {
+			return ErrJDKNotFound
+		}
+	}
+	for _, jdk := range jdks {
+		c.Jdks[jdk.Name] = jdk
+	}
+	return c.doSave()
+}
+
 // RemoveJDK 移除JDK
 func (c *Config) RemoveJDK(name string) error {
 	c.lock.Lock()
@@ -219,6 +320,16 @@ func ValidateJavaPath(path string) bool {
 	}
 }
 
+// ValidateJavaRuntimePath 判断路径是否为可运行的 Java 运行时（JDK、JRE 或 jlink 镜像）
+func ValidateJavaRuntimePath(path string) bool {
+	java := filepath.Join(path, "bin", "java")
+	if runtime.GOOS == "windows" {
+		java += ".exe"
+	}
+	_, err := os.Stat(java)
+	return err == nil
+}
+
 func GetDefaultSymlinkPath() string {
 	switch runtime.GOOS {
 	case "windows":
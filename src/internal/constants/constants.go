@@ -11,6 +11,8 @@ const (
 	DEFAULT_CONFIG_FILE = "config.json"
 	DEFAULT_FOLDER      = ".jdks"
 	DEFAULT_BACKUP_FILE = "backup.json"
+	DEFAULT_SHIMS_DIR   = "shims"
+	DEFAULT_STORE_DIR   = "store"
 
 	// 默认符号链接路径
 	DEFAULT_SYMLINK_PATH_WINDOWS = "C:\\Java\\JAVA_HOME"
@@ -0,0 +1,185 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Eclipse keeps its installed JREs as an XML document in a preference of the workspace
+const (
+	eclipsePrefVMXML        = "org.eclipse.jdt.launching.PREF_VM_XML"
+	eclipsePrefsVersion     = "eclipse.preferences.version"
+	eclipseStandardVMType   = "org.eclipse.jdt.internal.debug.ui.launcher.StandardVMType"
+	eclipseLaunchingPrefs   = "org.eclipse.jdt.launching.prefs"
+	eclipseDefaultWorkspace = "eclipse-workspace"
+)
+
+// EclipsePrefsPath returns the JDT launching preferences of an Eclipse workspace,
+// ~/eclipse-workspace when workspace is empty
+func EclipsePrefsPath(workspace string) (string, error) {
+	if workspace == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		workspace = filepath.Join(home, eclipseDefaultWorkspace)
+	}
+	return filepath.Join(workspace, ".metadata", ".plugins", "org.eclipse.core.runtime", ".settings", eclipseLaunchingPrefs), nil
+}
+
+// eclipseVM is a <vm> element of the installed JREs document
+type eclipseVM struct {
+	ideEntry
+	ID         string
+	Start, End int
+}
+
+// MergeEclipse adds the JDKs to the installed JREs of an Eclipse workspace.
+// New JREs get numeric ids counting up from idBase, as Eclipse itself uses the
+// creation time; an updated JRE keeps its id so launch configurations and the
+// default JRE still refer to it.
+func MergeEclipse(existing []byte, jdks []IDEJDK, idBase int64) ([]byte, IDEResult, error) {
+	var result IDEResult
+	props := ParseProperties(existing)
+	document, _ := props.Get(eclipsePrefVMXML)
+	data := []byte(document)
+
+	vms, insert, vmType, err := parseEclipseVMs(data)
+	if err != nil {
+		return nil, result, err
+	}
+	entries := make([]ideEntry, len(vms))
+	for i, vm := range vms {
+		entries[i] = vm.ideEntry
+	}
+	actions, targets := planIDEMerge(entries, jdks)
+
+	replacements := make(map[int]IDEJDK)
+	var added bytes.Buffer
+	for _, jdk := range jdks {
+		result.record(jdk.Name, actions[jdk.Name])
+		switch actions[jdk.Name] {
+		case ideUpdate:
+			replacements[targets[jdk.Name]] = jdk
+		case ideAdd:
+			writeEclipseVM(&added, strconv.FormatInt(idBase, 10), jdk)
+			idBase++
+		}
+	}
+	if !result.Changed() {
+		return existing, result, nil
+	}
+
+	var buf bytes.Buffer
+	if len(bytes.TrimSpace(data)) == 0 {
+		buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="no"?>` + "\n")
+		buf.WriteString("<vmSettings>\n")
+		buf.WriteString(`<vmType id="` + eclipseStandardVMType + `">` + "\n")
+		buf.Write(added.Bytes())
+		buf.WriteString("</vmType>\n")
+		buf.WriteString("</vmSettings>\n")
+	} else {
+		pos := 0
+		for i, vm := range vms {
+			jdk, ok := replacements[i]
+			if !ok {
+				continue
+			}
+			buf.Write(data[pos:lineStart(data, vm.Start)])
+			writeEclipseVM(&buf, vm.ID, jdk)
+			pos = lineEnd(data, vm.End)
+		}
+		insert = lineStart(data, insert)
+		if insert < pos {
+			insert = pos
+		}
+		buf.Write(data[pos:insert])
+		if insert > 0 && data[insert-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+		if !vmType {
+			buf.WriteString(`<vmType id="` + eclipseStandardVMType + `">` + "\n")
+		}
+		buf.Write(added.Bytes())
+		if !vmType {
+			buf.WriteString("</vmType>\n")
+		}
+		buf.Write(data[insert:])
+	}
+
+	if _, ok := props.Get(eclipsePrefsVersion); !ok {
+		props.Set(eclipsePrefsVersion, "1", "")
+	}
+	props.Set(eclipsePrefVMXML, buf.String(), "")
+	return props.Bytes(), result, nil
+}
+
+// parseEclipseVMs finds the <vm> elements of the standard VM type and where new ones
+// go: before its closing tag, or before </vmSettings> when there is no such type yet
+func parseEclipseVMs(data []byte) ([]eclipseVM, int, bool, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, 0, false, nil
+	}
+	var vms []eclipseVM
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	depth, typeDepth := 0, -1
+	insert, rootEnd := -1, -1
+	for {
+		offset := int(decoder.InputOffset())
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, 0, false, fmt.Errorf("invalid %s: %v", eclipsePrefVMXML, err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if depth == 0 && t.Name.Local != "vmSettings" {
+				return nil, 0, false, fmt.Errorf("invalid %s: root element is <%s>", eclipsePrefVMXML, t.Name.Local)
+			}
+			if depth == 1 && t.Name.Local == "vmType" && attrValue(t, "id") == eclipseStandardVMType {
+				typeDepth = depth + 1
+			}
+			if typeDepth > 0 && depth == typeDepth && t.Name.Local == "vm" {
+				if err := decoder.Skip(); err != nil {
+					return nil, 0, false, fmt.Errorf("invalid %s: %v", eclipsePrefVMXML, err)
+				}
+				vms = append(vms, eclipseVM{
+					ideEntry: ideEntry{Name: attrValue(t, "name"), Home: filepath.FromSlash(attrValue(t, "path"))},
+					ID:       attrValue(t, "id"),
+					Start:    offset,
+					End:      int(decoder.InputOffset()),
+				})
+				continue
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+			if typeDepth > 0 && depth == typeDepth-1 && t.Name.Local == "vmType" {
+				insert, typeDepth = offset, -1
+			}
+			if depth == 0 {
+				rootEnd = offset
+			}
+		}
+	}
+	if rootEnd < 0 {
+		return nil, 0, false, fmt.Errorf("invalid %s: missing <vmSettings> element", eclipsePrefVMXML)
+	}
+	if insert < 0 {
+		return vms, rootEnd, false, nil
+	}
+	return vms, insert, true, nil
+}
+
+// writeEclipseVM writes a <vm> element; Eclipse derives the libraries from the home
+func writeEclipseVM(buf *bytes.Buffer, id string, jdk IDEJDK) {
+	buf.WriteString(`<vm id="` + attrEscaper.Replace(id) + `" name="` + attrEscaper.Replace(jdk.Name) +
+		`" path="` + attrEscaper.Replace(jdk.Home) + "\"/>\n")
+}
@@ -0,0 +1,92 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+// Gradle properties that control where Gradle looks for toolchains
+const (
+	GradleInstallationPaths = "org.gradle.java.installations.paths"
+	GradleAutoDetect        = "org.gradle.java.installations.auto-detect"
+)
+
+const gradleComment = "JDKs registered with jenv, written by 'jenv export gradle'; edits to this line are overwritten"
+
+// GradlePropertiesPath returns the gradle.properties to update: the one in projectDir
+// when given, otherwise the one in the Gradle user home ($GRADLE_USER_HOME or ~/.gradle)
+func GradlePropertiesPath(projectDir string) (string, error) {
+	if projectDir != "" {
+		return filepath.Join(projectDir, "gradle.properties"), nil
+	}
+	if dir := os.Getenv("GRADLE_USER_HOME"); dir != "" {
+		return filepath.Join(dir, "gradle.properties"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".gradle", "gradle.properties"), nil
+}
+
+// GradlePaths returns the Java homes to list in org.gradle.java.installations.paths
+func GradlePaths(jdks map[string]config.JDK) []string {
+	var paths []string
+	for _, jdk := range buildJDKs(jdks) {
+		paths = append(paths, jdk.Path)
+	}
+	return paths
+}
+
+// UpdateGradleProperties sets the installation paths, and auto-detect when it is not
+// nil, keeping every other property of the file
+func UpdateGradleProperties(existing []byte, paths []string, autoDetect *bool) []byte {
+	props := ParseProperties(existing)
+	props.Set(GradleInstallationPaths, strings.Join(paths, ","), gradleComment)
+	if autoDetect != nil {
+		props.Set(GradleAutoDetect, strconv.FormatBool(*autoDetect), "")
+	}
+	return props.Bytes()
+}
+
+// CheckGradleProperties compares a gradle.properties with the registered JDKs.
+// Drift entries are Java homes; a differing auto-detect setting is reported as changed.
+func CheckGradleProperties(existing []byte, paths []string, autoDetect *bool) Drift {
+	var drift Drift
+	props := ParseProperties(existing)
+
+	listed := make(map[string]bool)
+	if value, ok := props.Get(GradleInstallationPaths); ok {
+		for _, p := range strings.Split(value, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				listed[filepath.Clean(p)] = true
+			}
+		}
+	}
+	wanted := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		wanted[filepath.Clean(p)] = true
+		if !listed[filepath.Clean(p)] {
+			drift.Added = append(drift.Added, p)
+		}
+	}
+	for p := range listed {
+		if !wanted[p] {
+			drift.Removed = append(drift.Removed, p)
+		}
+	}
+	sort.Strings(drift.Removed)
+
+	if autoDetect != nil {
+		value, _ := props.Get(GradleAutoDetect)
+		if current, err := strconv.ParseBool(strings.TrimSpace(value)); err != nil || current != *autoDetect {
+			drift.Changed = append(drift.Changed, GradleAutoDetect)
+		}
+	}
+	return drift
+}
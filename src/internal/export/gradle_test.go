@@ -0,0 +1,68 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+func TestProperties(t *testing.T) {
+	input := "# Gradle 设置\norg.gradle.jvmargs=-Xmx2g \\\n    -Dfile.encoding=UTF-8\n! 旧注释\nkey\\ with\\ space : C:\\\\tools\n"
+	props := ParseProperties([]byte(input))
+
+	if v, ok := props.Get("org.gradle.jvmargs"); !ok || v != "-Xmx2g -Dfile.encoding=UTF-8" {
+		t.Errorf("续行解析错误: %q", v)
+	}
+	if v, ok := props.Get("key with space"); !ok || v != `C:\tools` {
+		t.Errorf("转义解析错误: %q", v)
+	}
+
+	props.Set("org.gradle.jvmargs", "-Xmx4g", "")
+	props.Set("new.key", `D:\jdk`, "新属性")
+	expected := "# Gradle 设置\norg.gradle.jvmargs=-Xmx4g\n! 旧注释\nkey\\ with\\ space : C:\\\\tools\n\n# 新属性\nnew.key=D:\\\\jdk\n"
+	if got := string(props.Bytes()); got != expected {
+		t.Errorf("输出错误:\n期望\n%s\n实际\n%s", expected, got)
+	}
+	if v, _ := ParseProperties(props.Bytes()).Get("new.key"); v != `D:\jdk` {
+		t.Errorf("写入后读取错误: %q", v)
+	}
+}
+
+func TestUpdateGradleProperties(t *testing.T) {
+	jdks := map[string]config.JDK{
+		"jdk17": {Name: "jdk17", Path: "/opt/jdk17", JavaRuntimeVersion: "17.0.9+9"},
+		"jdk11": {Name: "jdk11", Path: "/opt/jdk11", JavaRuntimeVersion: "11.0.21+9"},
+		"jre8":  {Name: "jre8", Path: "/opt/jre8", JavaRuntimeVersion: "1.8.0_392", Kind: config.KindJRE},
+	}
+	paths := GradlePaths(jdks)
+	if strings.Join(paths, ",") != "/opt/jdk11,/opt/jdk17" {
+		t.Fatalf("路径列表错误: %v", paths)
+	}
+
+	existing := []byte("org.gradle.caching=true\norg.gradle.java.installations.paths=/opt/old-jdk,/opt/jdk11\n")
+	if drift := CheckGradleProperties(existing, paths, nil); strings.Join(drift.Added, ",") != "/opt/jdk17" || strings.Join(drift.Removed, ",") != "/opt/old-jdk" {
+		t.Errorf("差异错误: %+v", drift)
+	}
+
+	off := false
+	updated := UpdateGradleProperties(existing, paths, &off)
+	out := string(updated)
+	if !strings.Contains(out, "org.gradle.caching=true\n") {
+		t.Errorf("其他属性应保留:\n%s", out)
+	}
+	if !strings.Contains(out, GradleInstallationPaths+"=/opt/jdk11,/opt/jdk17\n") || !strings.Contains(out, GradleAutoDetect+"=false\n") {
+		t.Errorf("输出错误:\n%s", out)
+	}
+	if drift := CheckGradleProperties(updated, paths, &off); !drift.InSync() {
+		t.Errorf("更新后仍有差异: %+v", drift)
+	}
+	if again := UpdateGradleProperties(updated, paths, &off); string(again) != out {
+		t.Errorf("重复更新结果不一致:\n%s", again)
+	}
+
+	on := true
+	if drift := CheckGradleProperties(updated, paths, &on); len(drift.Changed) != 1 {
+		t.Errorf("期望 auto-detect 差异: %+v", drift)
+	}
+}
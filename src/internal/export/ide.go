@@ -0,0 +1,111 @@
+package export
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/config"
+	"github.com/whywhathow/jenv/internal/java"
+)
+
+// IDE targets supported by ExportIDE
+const (
+	TargetIntelliJ = "intellij"
+	TargetVSCode   = "vscode"
+	TargetEclipse  = "eclipse"
+)
+
+// IDEJDK is a registered JDK as IDEs see it
+type IDEJDK struct {
+	Name    string
+	Home    string
+	Version java.Version
+	Modules []string // release 文件中的 MODULES，Java 8 为空
+}
+
+// IDEResult describes the changes made to one IDE configuration file
+type IDEResult struct {
+	Path      string
+	Added     []string // 新增的 JDK
+	Updated   []string // 同名但路径已变化、被更新的 JDK
+	Unchanged []string // IDE 中已存在的 JDK
+}
+
+// IDEJDKs returns the registered JDKs to export to IDEs, ordered by version
+func IDEJDKs(jdks map[string]config.JDK) []IDEJDK {
+	var result []IDEJDK
+	for _, jdk := range buildJDKs(jdks) {
+		v, _ := java.JDKVersion(jdk)
+		result = append(result, IDEJDK{Name: jdk.Name, Home: jdk.Path, Version: v, Modules: jdk.Modules})
+	}
+	return result
+}
+
+// ideAction is what a merge does with one JDK
+type ideAction int
+
+const (
+	ideAdd ideAction = iota
+	ideUpdate
+	ideKeep
+)
+
+// ideEntry is an SDK already configured in an IDE
+type ideEntry struct {
+	Name string
+	Home string
+}
+
+// planIDEMerge decides, for every JDK, whether the IDE already has it (same home),
+// has a stale entry of the same name to update, or needs a new entry.
+// It returns the action per JDK name and the index of the entry to update.
+func planIDEMerge(existing []ideEntry, jdks []IDEJDK) (map[string]ideAction, map[string]int) {
+	actions := make(map[string]ideAction, len(jdks))
+	targets := make(map[string]int)
+	for _, jdk := range jdks {
+		actions[jdk.Name] = ideAdd
+		for i, e := range existing {
+			if samePath(e.Home, jdk.Home) {
+				actions[jdk.Name] = ideKeep
+				break
+			}
+			if e.Name == jdk.Name {
+				actions[jdk.Name] = ideUpdate
+				targets[jdk.Name] = i
+			}
+		}
+		if actions[jdk.Name] == ideKeep {
+			delete(targets, jdk.Name)
+		}
+	}
+	return actions, targets
+}
+
+// record adds the outcome for jdk to the result
+func (r *IDEResult) record(name string, action ideAction) {
+	switch action {
+	case ideAdd:
+		r.Added = append(r.Added, name)
+	case ideUpdate:
+		r.Updated = append(r.Updated, name)
+	default:
+		r.Unchanged = append(r.Unchanged, name)
+	}
+}
+
+// Changed reports whether the merge modified the file
+func (r IDEResult) Changed() bool {
+	return len(r.Added) > 0 || len(r.Updated) > 0
+}
+
+func samePath(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	a, b = filepath.Clean(a), filepath.Clean(b)
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
@@ -0,0 +1,192 @@
+package export
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+func testIDEJDKs() []IDEJDK {
+	return IDEJDKs(map[string]config.JDK{
+		"jdk8":    {Name: "jdk8", Path: "/opt/jdk8", JavaRuntimeVersion: "1.8.0_392-b08"},
+		"jdk17":   {Name: "jdk17", Path: "/opt/jdk17", JavaRuntimeVersion: "17.0.9+9"},
+		"jdk17.1": {Name: "jdk17.1", Path: "/opt/jdk17.0.10", JavaRuntimeVersion: "17.0.10+7"},
+		"jdk21":   {Name: "jdk21", Path: "/opt/jdk21", JavaRuntimeVersion: "21.0.1+12", Modules: []string{"java.base", "java.sql"}},
+	})
+}
+
+const userJDKTable = `<application>
+  <component name="ProjectJdkTable">
+    <!-- 自己配置的 SDK -->
+    <jdk version="2">
+      <name value="my-17" />
+      <type value="JavaSDK" />
+      <homePath value="$USER_HOME$/opt/jdk17" />
+    </jdk>
+    <jdk version="2">
+      <name value="jdk21" />
+      <type value="JavaSDK" />
+      <homePath value="/old/jdk21" />
+    </jdk>
+  </component>
+</application>
+`
+
+func TestMergeIntelliJ(t *testing.T) {
+	jdks := testIDEJDKs()
+	for i := range jdks {
+		if jdks[i].Name == "jdk17" {
+			jdks[i].Home = "/home/me/opt/jdk17"
+		}
+	}
+
+	merged, result, err := MergeIntelliJ([]byte(userJDKTable), jdks, "/home/me")
+	if err != nil {
+		t.Fatalf("合并失败: %v", err)
+	}
+	if strings.Join(result.Unchanged, ",") != "jdk17" || strings.Join(result.Updated, ",") != "jdk21" ||
+		strings.Join(result.Added, ",") != "jdk8,jdk17.1" {
+		t.Errorf("合并结果错误: %+v", result)
+	}
+	out := string(merged)
+	for _, want := range []string{
+		"<!-- 自己配置的 SDK -->",
+		`<homePath value="$USER_HOME$/opt/jdk17" />`,
+		`<homePath value="/opt/jdk21" />`,
+		`<root url="jrt:///opt/jdk21!/java.sql" type="simple" />`,
+		`<name value="jdk8" />`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("输出缺少 %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "/old/jdk21") {
+		t.Errorf("旧路径应被更新:\n%s", out)
+	}
+
+	again, result, err := MergeIntelliJ(merged, jdks, "/home/me")
+	if err != nil || result.Changed() || string(again) != out {
+		t.Errorf("重复合并应无变化: %+v %v", result, err)
+	}
+}
+
+func TestMergeIntelliJEmpty(t *testing.T) {
+	merged, result, err := MergeIntelliJ(nil, testIDEJDKs(), "/home/me")
+	if err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+	if len(result.Added) != 4 || !strings.Contains(string(merged), `<component name="ProjectJdkTable">`) {
+		t.Errorf("生成结果错误: %+v\n%s", result, merged)
+	}
+	if _, _, _, err := parseIntelliJ(merged, "/home/me"); err != nil {
+		t.Errorf("生成的文件无法解析: %v", err)
+	}
+}
+
+const userSettings = `{
+    // 编辑器设置
+    "editor.fontSize": 14,
+    "java.configuration.runtimes": [
+        {"name": "JavaSE-17", "path": "/old/jdk17", "default": true},
+        {"name": "JavaSE-11", "path": "/opt/custom11"}, // 自己的 JDK
+    ],
+    "files.trimTrailingWhitespace": true,
+}
+`
+
+func TestMergeVSCode(t *testing.T) {
+	merged, result, err := MergeVSCode([]byte(userSettings), testIDEJDKs())
+	if err != nil {
+		t.Fatalf("合并失败: %v", err)
+	}
+	if strings.Join(result.Updated, ",") != "JavaSE-17" || strings.Join(result.Added, ",") != "JavaSE-1.8,JavaSE-21" {
+		t.Errorf("合并结果错误: %+v", result)
+	}
+	out := string(merged)
+	if !strings.Contains(out, "// 编辑器设置") || !strings.Contains(out, `"files.trimTrailingWhitespace": true,`) {
+		t.Errorf("其他设置和注释应保留:\n%s", out)
+	}
+
+	var settings struct {
+		Runtimes []struct {
+			Name    string `json:"name"`
+			Path    string `json:"path"`
+			Default bool   `json:"default"`
+		} `json:"java.configuration.runtimes"`
+	}
+	if err := json.Unmarshal(stripJSONC(merged), &settings); err != nil {
+		t.Fatalf("输出不是合法的 JSONC: %v\n%s", err, out)
+	}
+	runtimes := settings.Runtimes
+	if len(runtimes) != 4 || runtimes[0].Path != "/opt/jdk17.0.10" || !runtimes[0].Default || runtimes[1].Path != "/opt/custom11" {
+		t.Errorf("runtimes 错误: %+v", runtimes)
+	}
+
+	again, result, err := MergeVSCode(merged, testIDEJDKs())
+	if err != nil || result.Changed() || string(again) != out {
+		t.Errorf("重复合并应无变化: %+v %v", result, err)
+	}
+}
+
+func TestMergeVSCodeAddsSetting(t *testing.T) {
+	for _, input := range []string{"", "{}", "{\n    \"a\": 1\n}\n"} {
+		merged, _, err := MergeVSCode([]byte(input), testIDEJDKs())
+		if err != nil {
+			t.Fatalf("%q: 合并失败: %v", input, err)
+		}
+		var settings map[string]interface{}
+		if err := json.Unmarshal(merged, &settings); err != nil {
+			t.Errorf("%q: 输出不是合法 JSON: %v\n%s", input, err, merged)
+		}
+		if _, ok := settings[vscodeRuntimesKey]; !ok {
+			t.Errorf("%q: 缺少 %s", input, vscodeRuntimesKey)
+		}
+	}
+}
+
+func TestMergeEclipse(t *testing.T) {
+	existing := "eclipse.preferences.version=1\n" + eclipsePrefVMXML + "=" + escapeProperty(`<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<vmSettings defaultVM="57,org.eclipse.jdt.internal.debug.ui.launcher.StandardVMType13,100">
+<vmType id="org.eclipse.jdt.internal.debug.ui.launcher.StandardVMType">
+<vm id="100" name="jdk21" path="/old/jdk21"/>
+<vm id="101" name="system" path="/usr/lib/jvm/java-17">
+<libraryLocations/>
+</vm>
+</vmType>
+</vmSettings>
+`) + "\n"
+
+	merged, result, err := MergeEclipse([]byte(existing), testIDEJDKs(), 5000)
+	if err != nil {
+		t.Fatalf("合并失败: %v", err)
+	}
+	if strings.Join(result.Updated, ",") != "jdk21" || len(result.Added) != 3 {
+		t.Errorf("合并结果错误: %+v", result)
+	}
+	document, _ := ParseProperties(merged).Get(eclipsePrefVMXML)
+	for _, want := range []string{
+		`defaultVM="57,org.eclipse.jdt.internal.debug.ui.launcher.StandardVMType13,100"`,
+		`<vm id="100" name="jdk21" path="/opt/jdk21"/>`,
+		`<vm id="101" name="system" path="/usr/lib/jvm/java-17">`,
+		`<vm id="5000" name="jdk8" path="/opt/jdk8"/>`,
+	} {
+		if !strings.Contains(document, want) {
+			t.Errorf("输出缺少 %q:\n%s", want, document)
+		}
+	}
+
+	again, result, err := MergeEclipse(merged, testIDEJDKs(), 6000)
+	if err != nil || result.Changed() || string(again) != string(merged) {
+		t.Errorf("重复合并应无变化: %+v %v", result, err)
+	}
+
+	fresh, _, err := MergeEclipse(nil, testIDEJDKs(), 1)
+	if err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+	if v, ok := ParseProperties(fresh).Get(eclipsePrefsVersion); !ok || v != "1" {
+		t.Errorf("缺少 %s:\n%s", eclipsePrefsVersion, fresh)
+	}
+}
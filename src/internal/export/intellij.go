@@ -0,0 +1,281 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// intellijAnnotations is the external annotations jar IntelliJ attaches to every JDK
+const intellijAnnotations = "jar://$APPLICATION_HOME_DIR$/plugins/java/lib/resources/jdkAnnotations.jar!/"
+
+// IntelliJConfigFiles returns options/jdk.table.xml of every IntelliJ IDEA
+// (Ultimate and Community) configuration directory of the current user
+func IntelliJConfigFiles() ([]string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, pattern := range []string{"IntelliJIdea*", "IdeaIC*"} {
+		dirs, _ := filepath.Glob(filepath.Join(base, "JetBrains", pattern))
+		for _, dir := range dirs {
+			if info, err := os.Stat(dir); err == nil && info.IsDir() {
+				files = append(files, filepath.Join(dir, "options", "jdk.table.xml"))
+			}
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// intellijJDK is a <jdk> element of an existing jdk.table.xml
+type intellijJDK struct {
+	ideEntry
+	Start, End int
+}
+
+// MergeIntelliJ adds the JDKs to an IntelliJ jdk.table.xml. SDKs IntelliJ already
+// has for the same home are left alone, an SDK with the same name but another home
+// is pointed at the JDK, and everything else in the file is kept byte for byte.
+// userHome expands the $USER_HOME$ macro IntelliJ uses in paths.
+func MergeIntelliJ(existing []byte, jdks []IDEJDK, userHome string) ([]byte, IDEResult, error) {
+	var result IDEResult
+	if len(bytes.TrimSpace(existing)) == 0 {
+		var buf bytes.Buffer
+		buf.WriteString("<application>\n")
+		buf.WriteString("  <component name=\"ProjectJdkTable\">\n")
+		for _, jdk := range jdks {
+			writeIntelliJJDK(&buf, jdk)
+			result.record(jdk.Name, ideAdd)
+		}
+		buf.WriteString("  </component>\n")
+		buf.WriteString("</application>\n")
+		return buf.Bytes(), result, nil
+	}
+
+	entries, insert, component, err := parseIntelliJ(existing, userHome)
+	if err != nil {
+		return nil, result, err
+	}
+	existingEntries := make([]ideEntry, len(entries))
+	for i, e := range entries {
+		existingEntries[i] = e.ideEntry
+	}
+	actions, targets := planIDEMerge(existingEntries, jdks)
+
+	replacements := make(map[int]IDEJDK)
+	var added bytes.Buffer
+	for _, jdk := range jdks {
+		result.record(jdk.Name, actions[jdk.Name])
+		switch actions[jdk.Name] {
+		case ideUpdate:
+			replacements[targets[jdk.Name]] = jdk
+		case ideAdd:
+			writeIntelliJJDK(&added, jdk)
+		}
+	}
+	if !result.Changed() {
+		return existing, result, nil
+	}
+
+	var buf bytes.Buffer
+	pos := 0
+	for i, e := range entries {
+		jdk, ok := replacements[i]
+		if !ok {
+			continue
+		}
+		start := lineStart(existing, e.Start)
+		buf.Write(existing[pos:start])
+		writeIntelliJJDK(&buf, jdk)
+		pos = lineEnd(existing, e.End)
+	}
+	insert = lineStart(existing, insert)
+	if insert < pos {
+		insert = pos
+	}
+	buf.Write(existing[pos:insert])
+	if insert > 0 && existing[insert-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+	if !component {
+		buf.WriteString("  <component name=\"ProjectJdkTable\">\n")
+	}
+	buf.Write(added.Bytes())
+	if !component {
+		buf.WriteString("  </component>\n")
+	}
+	buf.Write(existing[insert:])
+	return buf.Bytes(), result, nil
+}
+
+// parseIntelliJ finds the <jdk> elements of the ProjectJdkTable component and where
+// new ones go: before the component's closing tag, or before </application> when
+// the file has no such component yet (component reports which)
+func parseIntelliJ(data []byte, userHome string) ([]intellijJDK, int, bool, error) {
+	type valueAttr struct {
+		Value string `xml:"value,attr"`
+	}
+	type rawJDK struct {
+		Name     valueAttr `xml:"name"`
+		HomePath valueAttr `xml:"homePath"`
+	}
+
+	var entries []intellijJDK
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	depth, tableDepth := 0, -1
+	insert, rootEnd := -1, -1
+	for {
+		offset := int(decoder.InputOffset())
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, 0, false, fmt.Errorf("invalid jdk.table.xml: %v", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if depth == 0 && t.Name.Local != "application" {
+				return nil, 0, false, fmt.Errorf("invalid jdk.table.xml: root element is <%s>", t.Name.Local)
+			}
+			if t.Name.Local == "component" && attrValue(t, "name") == "ProjectJdkTable" {
+				tableDepth = depth + 1
+			}
+			if tableDepth > 0 && depth == tableDepth && t.Name.Local == "jdk" {
+				var raw rawJDK
+				if err := decoder.DecodeElement(&raw, &t); err != nil {
+					return nil, 0, false, fmt.Errorf("invalid jdk.table.xml: %v", err)
+				}
+				home := strings.ReplaceAll(raw.HomePath.Value, "$USER_HOME$", filepath.ToSlash(userHome))
+				entries = append(entries, intellijJDK{
+					ideEntry: ideEntry{Name: raw.Name.Value, Home: filepath.FromSlash(home)},
+					Start:    offset,
+					End:      int(decoder.InputOffset()),
+				})
+				continue
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+			if tableDepth > 0 && depth == tableDepth-1 && t.Name.Local == "component" {
+				insert, tableDepth = offset, -1
+			}
+			if depth == 0 {
+				rootEnd = offset
+			}
+		}
+	}
+	if rootEnd < 0 {
+		return nil, 0, false, errors.New("invalid jdk.table.xml: missing <application> element")
+	}
+	if insert < 0 {
+		return entries, rootEnd, false, nil
+	}
+	return entries, insert, true, nil
+}
+
+// attrEscaper escapes attribute values the way IntelliJ and Eclipse write them
+var attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "\n", "&#10;")
+
+func attrValue(e xml.StartElement, name string) string {
+	for _, a := range e.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// writeIntelliJJDK writes a <jdk> element the way IntelliJ itself stores a JavaSDK
+func writeIntelliJJDK(buf *bytes.Buffer, jdk IDEJDK) {
+	home := filepath.ToSlash(jdk.Home)
+	buf.WriteString("    <jdk version=\"2\">\n")
+	writeValueElement(buf, 6, "name", jdk.Name)
+	writeValueElement(buf, 6, "type", "JavaSDK")
+	writeValueElement(buf, 6, "version", fmt.Sprintf("java version %q", jdk.Version.Raw))
+	writeValueElement(buf, 6, "homePath", home)
+	buf.WriteString("      <roots>\n")
+	writeRoots(buf, "annotationsPath", []string{intellijAnnotations})
+	writeRoots(buf, "classPath", intellijClassRoots(jdk))
+	writeRoots(buf, "javadocPath", nil)
+	writeRoots(buf, "sourcePath", intellijSourceRoots(jdk))
+	buf.WriteString("      </roots>\n")
+	buf.WriteString("      <additional />\n")
+	buf.WriteString("    </jdk>\n")
+}
+
+func writeValueElement(buf *bytes.Buffer, indent int, name, value string) {
+	buf.WriteString(strings.Repeat(" ", indent) + "<" + name + " value=\"" + attrEscaper.Replace(value) + "\" />\n")
+}
+
+func writeRoots(buf *bytes.Buffer, kind string, urls []string) {
+	buf.WriteString("        <" + kind + ">\n")
+	if len(urls) == 0 {
+		buf.WriteString("          <root type=\"composite\" />\n")
+	} else {
+		buf.WriteString("          <root type=\"composite\">\n")
+		for _, url := range urls {
+			buf.WriteString("            <root url=\"" + attrEscaper.Replace(url) + "\" type=\"simple\" />\n")
+		}
+		buf.WriteString("          </root>\n")
+	}
+	buf.WriteString("        </" + kind + ">\n")
+}
+
+// intellijClassRoots lists the jrt module roots of a modular JDK, or the jars of a Java 8 JRE
+func intellijClassRoots(jdk IDEJDK) []string {
+	home := filepath.ToSlash(jdk.Home)
+	if jdk.Version.Feature() >= 9 {
+		modules := jdk.Modules
+		if len(modules) == 0 {
+			modules = []string{"java.base"}
+		}
+		urls := make([]string, len(modules))
+		for i, m := range modules {
+			urls[i] = "jrt://" + home + "!/" + m
+		}
+		return urls
+	}
+
+	var urls []string
+	for _, pattern := range []string{"jre/lib/*.jar", "jre/lib/ext/*.jar"} {
+		jars, _ := filepath.Glob(filepath.Join(jdk.Home, filepath.FromSlash(pattern)))
+		sort.Strings(jars)
+		for _, jar := range jars {
+			urls = append(urls, "jar://"+filepath.ToSlash(jar)+"!/")
+		}
+	}
+	return urls
+}
+
+// intellijSourceRoots points at src.zip when the JDK ships it
+func intellijSourceRoots(jdk IDEJDK) []string {
+	if src := filepath.Join(jdk.Home, "lib", "src.zip"); fileExists(src) && jdk.Version.Feature() >= 9 {
+		modules := jdk.Modules
+		if len(modules) == 0 {
+			return []string{"jar://" + filepath.ToSlash(src) + "!/"}
+		}
+		urls := make([]string, len(modules))
+		for i, m := range modules {
+			urls[i] = "jar://" + filepath.ToSlash(src) + "!/" + m
+		}
+		return urls
+	}
+	if src := filepath.Join(jdk.Home, "src.zip"); fileExists(src) {
+		return []string{"jar://" + filepath.ToSlash(src) + "!/"}
+	}
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,219 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// VS Code settings are JSON with comments and trailing commas (JSONC). These helpers
+// edit one top-level member in place so the rest of the file keeps its comments.
+
+// jsoncObject describes the top-level object of a JSONC document
+type jsoncObject struct {
+	Open, Close int             // '{' 和 '}' 的位置
+	LastEnd     int             // 最后一个成员值的结束位置，没有成员时为 -1
+	Members     map[string]span // 成员值的字节范围
+}
+
+type span struct {
+	Start, End int
+}
+
+var errJSONC = errors.New("invalid JSON")
+
+// parseJSONCObject scans the members of the top-level object of data
+func parseJSONCObject(data []byte) (jsoncObject, error) {
+	obj := jsoncObject{LastEnd: -1, Members: make(map[string]span)}
+	i := skipJSONCSpace(data, 0)
+	if i >= len(data) || data[i] != '{' {
+		return obj, fmt.Errorf("%w: expected an object", errJSONC)
+	}
+	obj.Open = i
+	i = skipJSONCSpace(data, i+1)
+	for {
+		if i >= len(data) {
+			return obj, fmt.Errorf("%w: unterminated object", errJSONC)
+		}
+		if data[i] == '}' {
+			obj.Close = i
+			return obj, nil
+		}
+		if data[i] != '"' {
+			return obj, fmt.Errorf("%w: expected a key at offset %d", errJSONC, i)
+		}
+		keyEnd, err := skipJSONCString(data, i)
+		if err != nil {
+			return obj, err
+		}
+		key, err := unquoteJSON(data[i:keyEnd])
+		if err != nil {
+			return obj, err
+		}
+		i = skipJSONCSpace(data, keyEnd)
+		if i >= len(data) || data[i] != ':' {
+			return obj, fmt.Errorf("%w: expected ':' at offset %d", errJSONC, i)
+		}
+		start := skipJSONCSpace(data, i+1)
+		end, err := skipJSONCValue(data, start)
+		if err != nil {
+			return obj, err
+		}
+		obj.Members[key] = span{Start: start, End: end}
+		obj.LastEnd = end
+		i = skipJSONCSpace(data, end)
+		if i < len(data) && data[i] == ',' {
+			i = skipJSONCSpace(data, i+1)
+		}
+	}
+}
+
+// setJSONCMember replaces the value of a top-level member, or adds the member at the
+// end of the object. value must already be formatted JSON.
+func setJSONCMember(data []byte, obj jsoncObject, key, value string) []byte {
+	var out []byte
+	if s, ok := obj.Members[key]; ok {
+		out = append(out, data[:s.Start]...)
+		out = append(out, value...)
+		return append(out, data[s.End:]...)
+	}
+
+	member := fmt.Sprintf("\n    %q: %s", key, value)
+	if obj.LastEnd < 0 {
+		out = append(out, data[:obj.Open+1]...)
+		out = append(out, member+"\n"...)
+		return append(out, data[obj.Close:]...)
+	}
+	out = append(out, data[:obj.LastEnd]...)
+	out = append(out, ","+member...)
+	return append(out, data[obj.LastEnd:]...)
+}
+
+// stripJSONC removes comments and trailing commas so encoding/json can parse data
+func stripJSONC(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); {
+		switch {
+		case data[i] == '"':
+			end, err := skipJSONCString(data, i)
+			if err != nil {
+				return append(out, data[i:]...)
+			}
+			out = append(out, data[i:end]...)
+			i = end
+		case isJSONCCommentStart(data, i):
+			i = skipJSONCComment(data, i)
+		case data[i] == ',':
+			next := skipJSONCSpace(data, i+1)
+			if next < len(data) && (data[next] == '}' || data[next] == ']') {
+				i++
+				continue
+			}
+			out = append(out, ',')
+			i++
+		default:
+			out = append(out, data[i])
+			i++
+		}
+	}
+	return out
+}
+
+func skipJSONCSpace(data []byte, i int) int {
+	for i < len(data) {
+		switch {
+		case data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r':
+			i++
+		case isJSONCCommentStart(data, i):
+			i = skipJSONCComment(data, i)
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+func isJSONCCommentStart(data []byte, i int) bool {
+	return data[i] == '/' && i+1 < len(data) && (data[i+1] == '/' || data[i+1] == '*')
+}
+
+func skipJSONCComment(data []byte, i int) int {
+	if data[i+1] == '/' {
+		for i < len(data) && data[i] != '\n' {
+			i++
+		}
+		return i
+	}
+	for i += 2; i+1 < len(data); i++ {
+		if data[i] == '*' && data[i+1] == '/' {
+			return i + 2
+		}
+	}
+	return len(data)
+}
+
+// skipJSONCString returns the offset just past the string starting at i
+func skipJSONCString(data []byte, i int) (int, error) {
+	for j := i + 1; j < len(data); j++ {
+		switch data[j] {
+		case '\\':
+			j++
+		case '"':
+			return j + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unterminated string", errJSONC)
+}
+
+// skipJSONCValue returns the offset just past the value starting at i
+func skipJSONCValue(data []byte, i int) (int, error) {
+	if i >= len(data) {
+		return 0, fmt.Errorf("%w: missing value", errJSONC)
+	}
+	switch data[i] {
+	case '"':
+		return skipJSONCString(data, i)
+	case '{', '[':
+		depth := 0
+		for j := i; j < len(data); {
+			switch {
+			case data[j] == '"':
+				end, err := skipJSONCString(data, j)
+				if err != nil {
+					return 0, err
+				}
+				j = end
+				continue
+			case isJSONCCommentStart(data, j):
+				j = skipJSONCComment(data, j)
+				continue
+			case data[j] == '{' || data[j] == '[':
+				depth++
+			case data[j] == '}' || data[j] == ']':
+				depth--
+				if depth == 0 {
+					return j + 1, nil
+				}
+			}
+			j++
+		}
+		return 0, fmt.Errorf("%w: unterminated value", errJSONC)
+	}
+	j := i
+	for j < len(data) && data[j] != ',' && data[j] != '}' && data[j] != ']' &&
+		data[j] != ' ' && data[j] != '\t' && data[j] != '\n' && data[j] != '\r' && !isJSONCCommentStart(data, j) {
+		j++
+	}
+	if j == i {
+		return 0, fmt.Errorf("%w: missing value at offset %d", errJSONC, i)
+	}
+	return j, nil
+}
+
+func unquoteJSON(data []byte) (string, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", fmt.Errorf("%w: %v", errJSONC, err)
+	}
+	return s, nil
+}
@@ -0,0 +1,328 @@
+// Package export writes the JDKs registered with jenv into the configuration of build tools and IDEs.
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/config"
+	"github.com/whywhathow/jenv/internal/java"
+)
+
+// toolchainIDPrefix marks the toolchains jenv manages in toolchains.xml
+const toolchainIDPrefix = "jenv:"
+
+// Toolchain is a JDK toolchain entry in Maven's toolchains.xml
+type Toolchain struct {
+	Name    string // jenv 中的 JDK 名称
+	Version string
+	Vendor  string
+	JDKHome string
+}
+
+func (t Toolchain) id() string {
+	return toolchainIDPrefix + t.Name
+}
+
+// Drift lists how an exported file differs from the registered JDKs
+type Drift struct {
+	Added   []string // 已注册但文件中缺少的 JDK
+	Changed []string // 文件中的条目与已注册的 JDK 不一致
+	Removed []string // 文件中由 jenv 管理、但已不再注册的 JDK
+}
+
+// InSync reports whether there is no drift
+func (d Drift) InSync() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// DefaultToolchainsPath returns ~/.m2/toolchains.xml
+func DefaultToolchainsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".m2", "toolchains.xml"), nil
+}
+
+// MavenToolchains returns one toolchain per registered JDK that can compile code
+// on this machine. JREs, runtime images and cross-target JDKs are left out.
+func MavenToolchains(jdks map[string]config.JDK) []Toolchain {
+	var toolchains []Toolchain
+	for _, jdk := range buildJDKs(jdks) {
+		v, _ := java.JDKVersion(jdk)
+		vendor := jdk.Vendor
+		if vendor == java.VendorUnknown {
+			vendor = ""
+		}
+		toolchains = append(toolchains, Toolchain{Name: jdk.Name, Version: toolchainVersion(v), Vendor: vendor, JDKHome: jdk.Path})
+	}
+	return toolchains
+}
+
+// buildJDKs returns the registered JDKs build tools can use: those with javac, a
+// known version and an architecture this machine runs, ordered by version and name
+func buildJDKs(jdks map[string]config.JDK) []config.JDK {
+	type entry struct {
+		jdk     config.JDK
+		version java.Version
+	}
+	var entries []entry
+	for _, jdk := range jdks {
+		if !jdk.HasCompiler() || jdk.CrossTarget {
+			continue
+		}
+		if v, ok := java.JDKVersion(jdk); ok {
+			entries = append(entries, entry{jdk: jdk, version: v})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if c := entries[i].version.Compare(entries[j].version); c != 0 {
+			return c < 0
+		}
+		return entries[i].jdk.Name < entries[j].jdk.Name
+	})
+
+	result := make([]config.JDK, len(entries))
+	for i, e := range entries {
+		result[i] = e.jdk
+	}
+	return result
+}
+
+// toolchainVersion follows the Maven convention: 1.8 for Java 8 and older, the feature release after that
+func toolchainVersion(v java.Version) string {
+	if v.Feature() <= 8 {
+		return "1." + strconv.Itoa(v.Feature())
+	}
+	return strconv.Itoa(v.Feature())
+}
+
+// parsedToolchain is a <toolchain> element found in an existing file
+type parsedToolchain struct {
+	Start, End int // 元素在文件中的字节范围
+	Type       string
+	Provides   map[string]string
+	JDKHome    string
+}
+
+// managedName returns the jenv JDK name if jenv manages the toolchain. Toolchains
+// without the jenv id are adopted when they point at the home of a registered JDK.
+func (p parsedToolchain) managedName(byHome map[string]string) (string, bool) {
+	if p.Type != "jdk" {
+		return "", false
+	}
+	if id := p.Provides["id"]; strings.HasPrefix(id, toolchainIDPrefix) {
+		return strings.TrimPrefix(id, toolchainIDPrefix), true
+	}
+	name, ok := byHome[filepath.Clean(p.JDKHome)]
+	return name, ok
+}
+
+// parseToolchains finds the <toolchain> elements of a toolchains.xml and the
+// offset of its closing </toolchains> tag
+func parseToolchains(data []byte) ([]parsedToolchain, int, error) {
+	type rawToolchain struct {
+		Type     string `xml:"type"`
+		Provides struct {
+			Items []struct {
+				XMLName xml.Name
+				Value   string `xml:",chardata"`
+			} `xml:",any"`
+		} `xml:"provides"`
+		JDKHome string `xml:"configuration>jdkHome"`
+	}
+
+	var toolchains []parsedToolchain
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	depth, closing := 0, -1
+	for {
+		offset := int(decoder.InputOffset())
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid toolchains.xml: %v", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if depth == 0 && t.Name.Local != "toolchains" {
+				return nil, 0, fmt.Errorf("invalid toolchains.xml: root element is <%s>", t.Name.Local)
+			}
+			if depth == 1 && t.Name.Local == "toolchain" {
+				var raw rawToolchain
+				if err := decoder.DecodeElement(&raw, &t); err != nil {
+					return nil, 0, fmt.Errorf("invalid toolchains.xml: %v", err)
+				}
+				parsed := parsedToolchain{
+					Start:    offset,
+					End:      int(decoder.InputOffset()),
+					Type:     strings.TrimSpace(raw.Type),
+					Provides: make(map[string]string),
+					JDKHome:  strings.TrimSpace(raw.JDKHome),
+				}
+				for _, item := range raw.Provides.Items {
+					parsed.Provides[item.XMLName.Local] = strings.TrimSpace(item.Value)
+				}
+				toolchains = append(toolchains, parsed)
+				continue
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+			if depth == 0 {
+				closing = offset
+			}
+		}
+	}
+	if closing < 0 {
+		return nil, 0, errors.New("invalid toolchains.xml: missing <toolchains> element")
+	}
+	return toolchains, closing, nil
+}
+
+// MergeToolchains returns existing with the jenv-managed toolchains replaced by managed.
+// Toolchains the user added are kept byte for byte. An empty existing file yields a new document.
+func MergeToolchains(existing []byte, managed []Toolchain) ([]byte, error) {
+	if len(bytes.TrimSpace(existing)) == 0 {
+		var buf bytes.Buffer
+		buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+		buf.WriteString(`<toolchains xmlns="http://maven.apache.org/TOOLCHAINS/1.1.0"` + "\n")
+		buf.WriteString(`            xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"` + "\n")
+		buf.WriteString(`            xsi:schemaLocation="http://maven.apache.org/TOOLCHAINS/1.1.0 https://maven.apache.org/xsd/toolchains-1.1.0.xsd">` + "\n")
+		writeToolchains(&buf, managed)
+		buf.WriteString("</toolchains>\n")
+		return buf.Bytes(), nil
+	}
+
+	parsed, closing, err := parseToolchains(existing)
+	if err != nil {
+		return nil, err
+	}
+	byHome := homeIndex(managed)
+
+	var buf bytes.Buffer
+	pos := 0
+	for _, p := range parsed {
+		if _, ok := p.managedName(byHome); !ok {
+			continue
+		}
+		start := lineStart(existing, p.Start)
+		buf.Write(existing[pos:start])
+		pos = lineEnd(existing, p.End)
+	}
+	insert := lineStart(existing, closing)
+	if insert < pos {
+		insert = pos
+	}
+	buf.Write(existing[pos:insert])
+	if insert > 0 && existing[insert-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+	writeToolchains(&buf, managed)
+	buf.Write(existing[insert:])
+	return buf.Bytes(), nil
+}
+
+// CheckToolchains compares an existing toolchains.xml with the registered JDKs
+func CheckToolchains(existing []byte, managed []Toolchain) (Drift, error) {
+	var drift Drift
+	found := make(map[string]parsedToolchain)
+	if len(bytes.TrimSpace(existing)) > 0 {
+		parsed, _, err := parseToolchains(existing)
+		if err != nil {
+			return drift, err
+		}
+		byHome := homeIndex(managed)
+		for _, p := range parsed {
+			if name, ok := p.managedName(byHome); ok {
+				found[name] = p
+			}
+		}
+	}
+
+	wanted := make(map[string]bool, len(managed))
+	for _, t := range managed {
+		wanted[t.Name] = true
+		p, ok := found[t.Name]
+		switch {
+		case !ok:
+			drift.Added = append(drift.Added, t.Name)
+		case p.Provides["id"] != t.id() || p.Provides["version"] != t.Version ||
+			p.Provides["vendor"] != t.Vendor || filepath.Clean(p.JDKHome) != filepath.Clean(t.JDKHome):
+			drift.Changed = append(drift.Changed, t.Name)
+		}
+	}
+	for name := range found {
+		if !wanted[name] {
+			drift.Removed = append(drift.Removed, name)
+		}
+	}
+	sort.Strings(drift.Removed)
+	return drift, nil
+}
+
+func homeIndex(managed []Toolchain) map[string]string {
+	byHome := make(map[string]string, len(managed))
+	for _, t := range managed {
+		byHome[filepath.Clean(t.JDKHome)] = t.Name
+	}
+	return byHome
+}
+
+func writeToolchains(buf *bytes.Buffer, toolchains []Toolchain) {
+	for _, t := range toolchains {
+		buf.WriteString("  <toolchain>\n")
+		buf.WriteString("    <type>jdk</type>\n")
+		buf.WriteString("    <provides>\n")
+		writeElement(buf, 6, "id", t.id())
+		writeElement(buf, 6, "version", t.Version)
+		if t.Vendor != "" {
+			writeElement(buf, 6, "vendor", t.Vendor)
+		}
+		buf.WriteString("    </provides>\n")
+		buf.WriteString("    <configuration>\n")
+		writeElement(buf, 6, "jdkHome", t.JDKHome)
+		buf.WriteString("    </configuration>\n")
+		buf.WriteString("  </toolchain>\n")
+	}
+}
+
+func writeElement(buf *bytes.Buffer, indent int, name, value string) {
+	buf.WriteString(strings.Repeat(" ", indent) + "<" + name + ">")
+	xml.EscapeText(buf, []byte(value))
+	buf.WriteString("</" + name + ">\n")
+}
+
+// lineStart moves offset back over the indentation before it, to the start of its line
+func lineStart(data []byte, offset int) int {
+	i := offset
+	for i > 0 && (data[i-1] == ' ' || data[i-1] == '\t') {
+		i--
+	}
+	if i == 0 || data[i-1] == '\n' {
+		return i
+	}
+	return offset
+}
+
+// lineEnd moves offset past trailing blanks and the newline after it
+func lineEnd(data []byte, offset int) int {
+	i := offset
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\r') {
+		i++
+	}
+	if i < len(data) && data[i] == '\n' {
+		return i + 1
+	}
+	return offset
+}
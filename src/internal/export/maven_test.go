@@ -0,0 +1,130 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+const userToolchains = `<?xml version="1.0" encoding="UTF-8"?>
+<toolchains>
+  <!-- 自己维护的工具链 -->
+  <toolchain>
+    <type>jdk</type>
+    <provides>
+      <version>11</version>
+      <vendor>custom</vendor>
+    </provides>
+    <configuration>
+      <jdkHome>/opt/custom-jdk11</jdkHome>
+    </configuration>
+  </toolchain>
+  <toolchain>
+    <type>jdk</type>
+    <provides>
+      <id>jenv:old-jdk</id>
+      <version>15</version>
+    </provides>
+    <configuration>
+      <jdkHome>/opt/jdk15</jdkHome>
+    </configuration>
+  </toolchain>
+  <toolchain>
+    <type>jdk</type>
+    <provides>
+      <version>17</version>
+    </provides>
+    <configuration>
+      <jdkHome>/opt/jdk17</jdkHome>
+    </configuration>
+  </toolchain>
+  <toolchain>
+    <type>protobuf</type>
+    <provides>
+      <version>3.25</version>
+    </provides>
+    <configuration>
+      <protocExecutable>/usr/bin/protoc</protocExecutable>
+    </configuration>
+  </toolchain>
+</toolchains>
+`
+
+func testToolchains() []Toolchain {
+	return MavenToolchains(map[string]config.JDK{
+		"jdk17": {Name: "jdk17", Path: "/opt/jdk17", JavaRuntimeVersion: "17.0.9+9", Vendor: "temurin"},
+		"jdk8":  {Name: "jdk8", Path: "/opt/jdk8", JavaRuntimeVersion: "1.8.0_392-b08", Vendor: "unknown"},
+		"jre21": {Name: "jre21", Path: "/opt/jre21", JavaRuntimeVersion: "21.0.1+12", Kind: config.KindJRE},
+		"arm":   {Name: "arm", Path: "/opt/arm", JavaRuntimeVersion: "21.0.1+12", CrossTarget: true},
+	})
+}
+
+func TestMavenToolchains(t *testing.T) {
+	toolchains := testToolchains()
+	if len(toolchains) != 2 {
+		t.Fatalf("期望 2 个工具链，实际 %+v", toolchains)
+	}
+	if toolchains[0].Name != "jdk8" || toolchains[0].Version != "1.8" || toolchains[0].Vendor != "" {
+		t.Errorf("jdk8 工具链错误: %+v", toolchains[0])
+	}
+	if toolchains[1].Version != "17" || toolchains[1].Vendor != "temurin" {
+		t.Errorf("jdk17 工具链错误: %+v", toolchains[1])
+	}
+}
+
+func TestMergeToolchains(t *testing.T) {
+	toolchains := testToolchains()
+	merged, err := MergeToolchains([]byte(userToolchains), toolchains)
+	if err != nil {
+		t.Fatalf("合并失败: %v", err)
+	}
+	out := string(merged)
+
+	for _, keep := range []string{"<!-- 自己维护的工具链 -->", "/opt/custom-jdk11", "<protocExecutable>/usr/bin/protoc</protocExecutable>"} {
+		if !strings.Contains(out, keep) {
+			t.Errorf("用户的工具链丢失 %q:\n%s", keep, out)
+		}
+	}
+	if strings.Contains(out, "jenv:old-jdk") {
+		t.Errorf("已移除的 JDK 应被删除:\n%s", out)
+	}
+	if strings.Count(out, "/opt/jdk17") != 1 || !strings.Contains(out, "<id>jenv:jdk17</id>") {
+		t.Errorf("指向已注册 JDK 的条目应被接管:\n%s", out)
+	}
+	if !strings.Contains(out, "<version>1.8</version>") || !strings.HasSuffix(out, "</toolchains>\n") {
+		t.Errorf("输出错误:\n%s", out)
+	}
+
+	// 合并后的文件应无差异，且再次合并结果不变
+	drift, err := CheckToolchains(merged, toolchains)
+	if err != nil || !drift.InSync() {
+		t.Errorf("合并后仍有差异: %+v %v", drift, err)
+	}
+	again, _ := MergeToolchains(merged, toolchains)
+	if string(again) != out {
+		t.Errorf("重复合并结果不一致:\n%s", again)
+	}
+}
+
+func TestCheckToolchains(t *testing.T) {
+	drift, err := CheckToolchains([]byte(userToolchains), testToolchains())
+	if err != nil {
+		t.Fatalf("检查失败: %v", err)
+	}
+	if strings.Join(drift.Added, ",") != "jdk8" || strings.Join(drift.Changed, ",") != "jdk17" || strings.Join(drift.Removed, ",") != "old-jdk" {
+		t.Errorf("差异错误: %+v", drift)
+	}
+
+	if _, err := CheckToolchains([]byte("<settings></settings>"), nil); err == nil {
+		t.Error("期望非 toolchains 文件报错")
+	}
+
+	created, err := MergeToolchains(nil, testToolchains())
+	if err != nil {
+		t.Fatalf("创建失败: %v", err)
+	}
+	if drift, _ := CheckToolchains(created, testToolchains()); !drift.InSync() {
+		t.Errorf("新建的文件应无差异: %+v", drift)
+	}
+}
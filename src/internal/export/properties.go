@@ -0,0 +1,159 @@
+package export
+
+import (
+	"strings"
+)
+
+// Properties is a Java .properties file edited in place. Comments, blank lines and
+// the layout of properties that are not changed are preserved.
+type Properties struct {
+	lines []string
+	eol   string
+}
+
+// ParseProperties splits a .properties file into lines
+func ParseProperties(data []byte) *Properties {
+	text := string(data)
+	p := &Properties{eol: "\n"}
+	if strings.Contains(text, "\r\n") {
+		p.eol = "\r\n"
+		text = strings.ReplaceAll(text, "\r\n", "\n")
+	}
+	text = strings.TrimSuffix(text, "\n")
+	if text != "" {
+		p.lines = strings.Split(text, "\n")
+	}
+	return p
+}
+
+// Bytes returns the file content, always ending with a newline
+func (p *Properties) Bytes() []byte {
+	if len(p.lines) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(p.lines, p.eol) + p.eol)
+}
+
+// Get returns the unescaped value of key
+func (p *Properties) Get(key string) (string, bool) {
+	start, end := p.find(key)
+	if start < 0 {
+		return "", false
+	}
+	logical := strings.TrimLeft(p.lines[start], " \t\f")
+	for i := start + 1; i < end; i++ {
+		logical = strings.TrimSuffix(logical, `\`) + strings.TrimLeft(p.lines[i], " \t\f")
+	}
+	_, value := splitProperty(logical)
+	return unescapeProperty(value), true
+}
+
+// Set replaces the value of key, or appends the property when it is missing.
+// A comment is written above newly added properties when one is given.
+func (p *Properties) Set(key, value, comment string) {
+	line := key + "=" + escapeProperty(value)
+	start, end := p.find(key)
+	if start >= 0 {
+		p.lines = append(p.lines[:start], append([]string{line}, p.lines[end:]...)...)
+		return
+	}
+	if n := len(p.lines); n > 0 && strings.TrimSpace(p.lines[n-1]) != "" && comment != "" {
+		p.lines = append(p.lines, "")
+	}
+	if comment != "" {
+		p.lines = append(p.lines, "# "+comment)
+	}
+	p.lines = append(p.lines, line)
+}
+
+// find returns the line range [start, end) of the logical line that defines key, or -1
+func (p *Properties) find(key string) (int, int) {
+	for i := 0; i < len(p.lines); {
+		trimmed := strings.TrimLeft(p.lines[i], " \t\f")
+		if trimmed == "" || trimmed[0] == '#' || trimmed[0] == '!' {
+			i++
+			continue
+		}
+		end := i + 1
+		for end < len(p.lines) && continues(p.lines[end-1]) {
+			end++
+		}
+		if k, _ := splitProperty(trimmed); unescapeProperty(k) == key {
+			return i, end
+		}
+		i = end
+	}
+	return -1, -1
+}
+
+// continues reports whether a line ends with an odd number of backslashes
+func continues(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// splitProperty splits a logical line at the first unescaped '=', ':' or whitespace
+func splitProperty(line string) (string, string) {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '=', ':':
+			return line[:i], strings.TrimLeft(line[i+1:], " \t\f")
+		case ' ', '\t', '\f':
+			rest := strings.TrimLeft(line[i:], " \t\f")
+			if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+				rest = rest[1:]
+			}
+			return line[:i], strings.TrimLeft(rest, " \t\f")
+		}
+	}
+	return line, ""
+}
+
+func escapeProperty(value string) string {
+	var b strings.Builder
+	for i, r := range value {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == ' ' && i == 0:
+			b.WriteString(`\ `)
+		case r == '\n':
+			b.WriteString(`\n`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func unescapeProperty(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i == len(value)-1 {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,125 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/java"
+)
+
+// vscodeRuntimesKey is the setting of the Java extension that lists available JDKs
+const vscodeRuntimesKey = "java.configuration.runtimes"
+
+// VSCodeSettingsPath returns the user settings.json of VS Code
+func VSCodeSettingsPath() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "Code", "User", "settings.json"), nil
+}
+
+// vscodeRuntime is an entry of java.configuration.runtimes
+type vscodeRuntime struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// VSCodeRuntimeName returns the execution environment name VS Code expects,
+// e.g. JavaSE-1.8 for Java 8 and JavaSE-17 for Java 17
+func VSCodeRuntimeName(v java.Version) string {
+	if f := v.Feature(); f <= 8 {
+		return fmt.Sprintf("JavaSE-1.%d", f)
+	}
+	return fmt.Sprintf("JavaSE-%d", v.Feature())
+}
+
+// vscodeJDKs keeps the newest JDK of every feature release, named after its
+// execution environment, since VS Code allows one runtime per name
+func vscodeJDKs(jdks []IDEJDK) []IDEJDK {
+	var result []IDEJDK
+	index := make(map[string]int)
+	for _, jdk := range jdks {
+		jdk.Name = VSCodeRuntimeName(jdk.Version)
+		if i, ok := index[jdk.Name]; ok {
+			if !jdk.Version.Less(result[i].Version) {
+				result[i] = jdk
+			}
+			continue
+		}
+		index[jdk.Name] = len(result)
+		result = append(result, jdk)
+	}
+	return result
+}
+
+// MergeVSCode sets java.configuration.runtimes in a VS Code settings.json. Runtimes
+// are named JavaSE-N; existing runtimes with other names or homes are kept, along
+// with their other fields such as "default". The rest of the file, comments
+// included, is not touched, but comments inside the runtimes list itself are lost
+// when it has to be rewritten.
+func MergeVSCode(existing []byte, jdks []IDEJDK) ([]byte, IDEResult, error) {
+	var result IDEResult
+	if len(bytes.TrimSpace(existing)) == 0 {
+		existing = []byte("{\n}\n")
+	}
+	obj, err := parseJSONCObject(existing)
+	if err != nil {
+		return nil, result, err
+	}
+
+	var runtimes []json.RawMessage
+	var entries []ideEntry
+	if s, ok := obj.Members[vscodeRuntimesKey]; ok {
+		if err := json.Unmarshal(stripJSONC(existing[s.Start:s.End]), &runtimes); err != nil {
+			return nil, result, fmt.Errorf("invalid %s: %v", vscodeRuntimesKey, err)
+		}
+		for _, raw := range runtimes {
+			var r vscodeRuntime
+			_ = json.Unmarshal(raw, &r)
+			entries = append(entries, ideEntry{Name: r.Name, Home: r.Path})
+		}
+	}
+
+	managed := vscodeJDKs(jdks)
+	actions, targets := planIDEMerge(entries, managed)
+	for _, jdk := range managed {
+		result.record(jdk.Name, actions[jdk.Name])
+		switch actions[jdk.Name] {
+		case ideUpdate:
+			// 只改 path，保留 default、sources 等字段
+			var fields map[string]interface{}
+			if err := json.Unmarshal(runtimes[targets[jdk.Name]], &fields); err != nil {
+				return nil, result, fmt.Errorf("invalid %s: %v", vscodeRuntimesKey, err)
+			}
+			fields["path"] = jdk.Home
+			raw, _ := json.Marshal(fields)
+			runtimes[targets[jdk.Name]] = raw
+		case ideAdd:
+			raw, _ := json.Marshal(vscodeRuntime{Name: jdk.Name, Path: jdk.Home})
+			runtimes = append(runtimes, raw)
+		}
+	}
+	if !result.Changed() {
+		return existing, result, nil
+	}
+
+	prefix, indent := "    ", "    "
+	if s, ok := obj.Members[vscodeRuntimesKey]; ok {
+		// 沿用成员所在行的缩进
+		line := existing[bytes.LastIndexByte(existing[:s.Start], '\n')+1 : s.Start]
+		prefix = string(line[:len(line)-len(bytes.TrimLeft(line, " \t"))])
+		if strings.Contains(prefix, "\t") {
+			indent = "\t"
+		}
+	}
+	value, err := json.MarshalIndent(runtimes, prefix, indent)
+	if err != nil {
+		return nil, result, err
+	}
+	return setJSONCMember(existing, obj, vscodeRuntimesKey, string(value)), result, nil
+}
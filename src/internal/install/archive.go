@@ -0,0 +1,348 @@
+// Package install puts JDKs from archives into the jenv store and registers them.
+package install
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrUnsafeArchive is returned for entries that would be written outside the target directory
+var ErrUnsafeArchive = errors.New("unsafe archive entry")
+
+// ErrUnsupportedArchive is returned for files that are neither .tar.gz nor .zip
+var ErrUnsupportedArchive = errors.New("unsupported archive format, expected .tar.gz or .zip")
+
+// Extract unpacks a .tar.gz or .zip archive into dir, keeping file permissions and
+// symbolic links. Entries with absolute paths, '..' components, links pointing
+// outside dir or paths through a link are refused. The format is taken from the
+// content, so misnamed downloads still work.
+func Extract(archive, dir string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(f, header); err != nil {
+		return fmt.Errorf("%s: %w", archive, ErrUnsupportedArchive)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	x := &extractor{root: dir, dirModes: make(map[string]os.FileMode), links: make(map[string]string)}
+	switch {
+	case bytes.HasPrefix(header, []byte{0x1f, 0x8b}):
+		err = x.tarGz(f)
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")):
+		info, statErr := f.Stat()
+		if statErr != nil {
+			return statErr
+		}
+		err = x.zip(f, info.Size())
+	default:
+		return fmt.Errorf("%s: %w", archive, ErrUnsupportedArchive)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", archive, err)
+	}
+	return x.finish()
+}
+
+type extractor struct {
+	root     string
+	dirModes map[string]os.FileMode // 目录权限在最后设置，避免只读目录阻止写入
+	links    map[string]string      // 已创建的符号链接，结束时重新检查
+}
+
+func (x *extractor) tarGz(r io.Reader) error {
+	gz, err := gzip.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		mode := hdr.FileInfo().Mode()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = x.dir(hdr.Name, mode)
+		case tar.TypeReg, tar.TypeRegA:
+			err = x.file(hdr.Name, mode, tr)
+		case tar.TypeSymlink:
+			err = x.symlink(hdr.Name, hdr.Linkname)
+		case tar.TypeLink:
+			err = x.hardlink(hdr.Name, hdr.Linkname)
+		case tar.TypeXGlobalHeader, tar.TypeXHeader:
+			// pax 元数据，无需处理
+		default:
+			// 设备文件、FIFO 等不会出现在 JDK 中
+			err = fmt.Errorf("%w: %s has unsupported type %q", ErrUnsafeArchive, hdr.Name, hdr.Typeflag)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (x *extractor) zip(r io.ReaderAt, size int64) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+	for _, f := range zr.File {
+		mode := f.Mode()
+		switch {
+		case mode.IsDir():
+			err = x.dir(f.Name, mode)
+		case mode&os.ModeSymlink != 0:
+			err = x.zipSymlink(f)
+		case mode.IsRegular():
+			err = x.zipFile(f, mode)
+		default:
+			err = fmt.Errorf("%w: %s has unsupported mode %v", ErrUnsafeArchive, f.Name, mode)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *extractor) zipFile(f *zip.File, mode os.FileMode) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if mode.Perm() == 0 {
+		// 没有记录 Unix 权限的 zip（多为 Windows 上打包）
+		mode |= 0644
+	}
+	return x.file(f.Name, mode, rc)
+}
+
+func (x *extractor) zipSymlink(f *zip.File) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	target, err := io.ReadAll(io.LimitReader(rc, 4096))
+	if err != nil {
+		return err
+	}
+	return x.symlink(f.Name, string(target))
+}
+
+// target maps an entry name to a path below the root, refusing anything that escapes it
+func (x *extractor) target(name string) (string, error) {
+	name = strings.ReplaceAll(name, `\`, "/")
+	if name == "" || path.IsAbs(name) || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", fmt.Errorf("%w: %s", ErrUnsafeArchive, name)
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == ".." {
+			return "", fmt.Errorf("%w: %s", ErrUnsafeArchive, name)
+		}
+	}
+	clean := path.Clean(name)
+	if clean == "." {
+		return x.root, nil
+	}
+
+	// 已解压的符号链接不能作为路径的一部分，否则写入会落到目标目录之外
+	dir := x.root
+	parts := strings.Split(clean, "/")
+	for _, part := range parts[:len(parts)-1] {
+		dir = filepath.Join(dir, part)
+		if info, err := os.Lstat(dir); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("%w: %s goes through a symbolic link", ErrUnsafeArchive, name)
+		}
+	}
+	return filepath.Join(x.root, filepath.FromSlash(clean)), nil
+}
+
+func (x *extractor) dir(name string, mode os.FileMode) error {
+	p, err := x.target(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(p, 0755); err != nil {
+		return err
+	}
+	x.dirModes[p] = mode.Perm() | 0700
+	return nil
+}
+
+func (x *extractor) file(name string, mode os.FileMode, r io.Reader) error {
+	p, err := x.target(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	// 先删除已存在的同名条目（可能是符号链接），避免写入其指向的文件
+	_ = os.Remove(p)
+	out, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode.Perm()|0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	// OpenFile 的权限受 umask 影响
+	return os.Chmod(p, mode.Perm()|0600)
+}
+
+func (x *extractor) symlink(name, linkname string) error {
+	p, err := x.target(name)
+	if err != nil {
+		return err
+	}
+	if err := x.linkInside(p, linkname); err != nil {
+		return fmt.Errorf("%w: %s -> %s", err, name, linkname)
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	_ = os.Remove(p)
+	if err := os.Symlink(filepath.FromSlash(linkname), p); err != nil {
+		return err
+	}
+	x.links[p] = linkname
+	return nil
+}
+
+func (x *extractor) hardlink(name, linkname string) error {
+	p, err := x.target(name)
+	if err != nil {
+		return err
+	}
+	src, err := x.target(linkname)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	_ = os.Remove(p)
+	return os.Link(src, p)
+}
+
+// linkInside checks that a relative symbolic link at p resolves below the root.
+// Links already extracted are followed the way the OS follows them, so a chain of
+// links that each stay inside cannot be combined to climb out of the root.
+func (x *extractor) linkInside(p, linkname string) error {
+	root, err := filepath.EvalSymlinks(x.root)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(x.root, filepath.Dir(p))
+	if err != nil {
+		return ErrUnsafeArchive
+	}
+	_, err = resolveInside(root, filepath.Join(root, rel), linkname, 0)
+	return err
+}
+
+// maxLinkDepth limits how many links resolveInside follows, like ELOOP does
+const maxLinkDepth = 40
+
+// resolveInside walks linkname from dir one component at a time, following the
+// symbolic links it finds on disk, and fails as soon as a step leaves root
+func resolveInside(root, dir, linkname string, depth int) (string, error) {
+	linkname = strings.ReplaceAll(linkname, `\`, "/")
+	if depth > maxLinkDepth || linkname == "" || path.IsAbs(linkname) || filepath.IsAbs(linkname) || filepath.VolumeName(linkname) != "" {
+		return "", ErrUnsafeArchive
+	}
+	cur := dir
+	for _, part := range strings.Split(linkname, "/") {
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			if cur == root {
+				return "", ErrUnsafeArchive
+			}
+			cur = filepath.Dir(cur)
+			continue
+		}
+		next := filepath.Join(cur, part)
+		if info, err := os.Lstat(next); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			target, err := os.Readlink(next)
+			if err != nil {
+				return "", err
+			}
+			if next, err = resolveInside(root, cur, target, depth+1); err != nil {
+				return "", err
+			}
+		}
+		cur = next
+	}
+	return cur, nil
+}
+
+// finish checks the symbolic links again, since a link extracted later can change
+// where an earlier one points, then applies directory permissions, deepest first
+func (x *extractor) finish() error {
+	for p, linkname := range x.links {
+		if err := x.linkInside(p, linkname); err != nil {
+			rel, _ := filepath.Rel(x.root, p)
+			return fmt.Errorf("%w: %s -> %s", err, filepath.ToSlash(rel), linkname)
+		}
+	}
+	dirs := make([]string, 0, len(x.dirModes))
+	for d := range x.dirModes {
+		dirs = append(dirs, d)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(dirs)))
+	for _, d := range dirs {
+		if err := os.Chmod(d, x.dirModes[d]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// javaHome finds the Java home inside an extracted archive: the single top-level
+// directory is stripped, and so is the Contents/Home layout of macOS bundles
+func javaHome(dir string) (string, error) {
+	home := dir
+	entries, err := os.ReadDir(home)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		home = filepath.Join(home, entries[0].Name())
+	}
+	if info, err := os.Stat(filepath.Join(home, "Contents", "Home")); err == nil && info.IsDir() {
+		home = filepath.Join(home, "Contents", "Home")
+	}
+	return home, nil
+}
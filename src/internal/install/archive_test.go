@@ -0,0 +1,154 @@
+package install
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+type entry struct {
+	name string
+	body string
+	mode int64
+	link string // 非空时为符号链接
+}
+
+func writeTarGz(t *testing.T, entries []entry) string {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: e.mode, Size: int64(len(e.body)), Typeflag: tar.TypeReg}
+		switch {
+		case e.link != "":
+			hdr.Typeflag, hdr.Linkname, hdr.Size = tar.TypeSymlink, e.link, 0
+		case e.name[len(e.name)-1] == '/':
+			hdr.Typeflag = tar.TypeDir
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("写入 tar 失败: %v", err)
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			tw.Write([]byte(e.body))
+		}
+	}
+	tw.Close()
+	gz.Close()
+	path := filepath.Join(t.TempDir(), "jdk.tar.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("写入文件失败: %v", err)
+	}
+	return path
+}
+
+func writeZip(t *testing.T, entries []entry) string {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		hdr := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		hdr.SetMode(os.FileMode(e.mode))
+		body := e.body
+		if e.link != "" {
+			hdr.SetMode(os.ModeSymlink | 0777)
+			body = e.link
+		}
+		w, err := zw.CreateHeader(hdr)
+		if err != nil {
+			t.Fatalf("写入 zip 失败: %v", err)
+		}
+		w.Write([]byte(body))
+	}
+	zw.Close()
+	path := filepath.Join(t.TempDir(), "jdk.zip")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("写入文件失败: %v", err)
+	}
+	return path
+}
+
+func TestExtractTarGz(t *testing.T) {
+	archive := writeTarGz(t, []entry{
+		{name: "jdk-17.0.9+9/", mode: 0755},
+		{name: "jdk-17.0.9+9/bin/java", body: "#!/bin/sh\n", mode: 0755},
+		{name: "jdk-17.0.9+9/bin/javac", body: "#!/bin/sh\n", mode: 0755},
+		{name: "jdk-17.0.9+9/release", body: "JAVA_VERSION=\"17.0.9\"\n", mode: 0644},
+		{name: "jdk-17.0.9+9/lib/libjli.so", body: "elf", mode: 0644},
+		{name: "jdk-17.0.9+9/bin/libjli.so", link: "../lib/libjli.so"},
+	})
+	dir := t.TempDir()
+	if err := Extract(archive, dir); err != nil {
+		t.Fatalf("解压失败: %v", err)
+	}
+	home, err := javaHome(dir)
+	if err != nil || home != filepath.Join(dir, "jdk-17.0.9+9") {
+		t.Fatalf("期望去掉顶层目录，实际 %s (%v)", home, err)
+	}
+	if runtime.GOOS != "windows" {
+		if info, err := os.Stat(filepath.Join(home, "bin", "javac")); err != nil || info.Mode().Perm()&0111 == 0 {
+			t.Errorf("可执行权限应保留: %v %v", info.Mode(), err)
+		}
+		if target, err := os.Readlink(filepath.Join(home, "bin", "libjli.so")); err != nil || target != "../lib/libjli.so" {
+			t.Errorf("符号链接应保留，实际 %q (%v)", target, err)
+		}
+	}
+}
+
+func TestExtractZipMacOSLayout(t *testing.T) {
+	archive := writeZip(t, []entry{
+		{name: "zulu-21.jdk/Contents/Info.plist", body: "<plist/>", mode: 0644},
+		{name: "zulu-21.jdk/Contents/Home/bin/javac", body: "bin", mode: 0755},
+		{name: "zulu-21.jdk/Contents/Home/release", body: "JAVA_VERSION=\"21.0.1\"\n", mode: 0644},
+		{name: "zulu-21.jdk/Contents/MacOS/libjli.dylib", link: "../Home/lib/libjli.dylib"},
+	})
+	dir := t.TempDir()
+	if err := Extract(archive, dir); err != nil {
+		t.Fatalf("解压失败: %v", err)
+	}
+	home, _ := javaHome(dir)
+	if home != filepath.Join(dir, "zulu-21.jdk", "Contents", "Home") {
+		t.Errorf("期望使用 Contents/Home，实际 %s", home)
+	}
+	if runtime.GOOS != "windows" {
+		if info, err := os.Stat(filepath.Join(home, "bin", "javac")); err != nil || info.Mode().Perm()&0111 == 0 {
+			t.Errorf("zip 中的可执行权限应保留: %v", err)
+		}
+	}
+}
+
+func TestExtractRejectsTraversal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows 上创建符号链接需要权限")
+	}
+	tests := map[string][]entry{
+		"上级目录":     {{name: "jdk/../../evil", body: "x", mode: 0644}},
+		"绝对路径":     {{name: "/tmp/evil", body: "x", mode: 0644}},
+		"链接指向外部":   {{name: "jdk/lib", link: "../../outside"}},
+		"绝对路径链接":   {{name: "jdk/lib", link: "/etc"}},
+		"经由链接写入文件": {{name: "jdk/lib", link: "."}, {name: "jdk/lib/x", body: "x", mode: 0644}},
+		// 每个链接按路径字符串看都在目录内，但经由 b/c/d/a 解析后会越过根目录
+		"链式链接":    {{name: "b/c/d/a", link: "../../.."}, {name: "x", link: "b/c/d/a/../../../.."}},
+		"链式链接后创建": {{name: "x", link: "b/c/d/a/../../../.."}, {name: "b/c/d/a", link: "../../.."}},
+	}
+	for name, entries := range tests {
+		for _, archive := range []string{writeTarGz(t, entries), writeZip(t, entries)} {
+			err := Extract(archive, t.TempDir())
+			if !errors.Is(err, ErrUnsafeArchive) {
+				t.Errorf("%s (%s): 期望 ErrUnsafeArchive，实际 %v", name, filepath.Ext(archive), err)
+			}
+		}
+	}
+
+	plain := filepath.Join(t.TempDir(), "jdk.txt")
+	os.WriteFile(plain, []byte("not an archive"), 0644)
+	if err := Extract(plain, t.TempDir()); !errors.Is(err, ErrUnsupportedArchive) {
+		t.Errorf("期望 ErrUnsupportedArchive，实际 %v", err)
+	}
+}
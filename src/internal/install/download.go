@@ -0,0 +1,87 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/provider"
+	"github.com/whywhathow/jenv/internal/verify"
+)
+
+// ErrChecksumMismatch is returned when a download does not match its published checksum
+var ErrChecksumMismatch = verify.ErrChecksumMismatch
+
+// FromPackage downloads a package found by a provider into the store, checks its
+// SHA-256 checksum when the provider publishes one, and installs it like FromArchive.
+// Packages of a local mirror are read in place.
+func FromPackage(ctx context.Context, client *http.Client, pkg provider.Package, opts Options) (Result, error) {
+	if pkg.URL == "" {
+		return Result{}, fmt.Errorf("no download URL for %s", pkg.Filename)
+	}
+	store := opts.Store
+	if store == "" {
+		var err error
+		if store, err = StoreDir(); err != nil {
+			return Result{}, err
+		}
+	}
+	if err := os.MkdirAll(store, 0755); err != nil {
+		return Result{}, err
+	}
+
+	archive := pkg.URL
+	if strings.HasPrefix(pkg.URL, "http://") || strings.HasPrefix(pkg.URL, "https://") {
+		dir, err := os.MkdirTemp(store, ".download-")
+		if err != nil {
+			return Result{}, err
+		}
+		defer os.RemoveAll(dir)
+		name := pkg.Filename
+		if name == "" || strings.ContainsAny(name, `/\`) {
+			name = "jdk-archive"
+		}
+		archive = filepath.Join(dir, name)
+		if err := download(ctx, client, pkg.URL, archive); err != nil {
+			return Result{}, err
+		}
+	}
+
+	opts.Store = store
+	if pkg.Checksum != "" {
+		opts.SHA256 = pkg.Checksum
+	}
+	return FromArchive(archive, opts)
+}
+
+func download(ctx context.Context, client *http.Client, url, dest string) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("download failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download failed: %s returned %s", url, resp.Status)
+	}
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		out.Close()
+		return fmt.Errorf("download failed: %v", err)
+	}
+	return out.Close()
+}
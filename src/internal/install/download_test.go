@@ -0,0 +1,40 @@
+package install
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/whywhathow/jenv/internal/provider"
+)
+
+func TestFromPackageChecksum(t *testing.T) {
+	archive := writeTarGz(t, []entry{{name: "jdk/bin/javac", body: "x", mode: 0755}})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/jdk.tar.gz" {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeFile(w, r, archive)
+	}))
+	defer server.Close()
+
+	store := t.TempDir()
+	pkg := provider.Package{Filename: "jdk.tar.gz", URL: server.URL + "/jdk.tar.gz", Checksum: strings.Repeat("0", 64)}
+	_, err := FromPackage(context.Background(), server.Client(), pkg, Options{Store: store})
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("期望 ErrChecksumMismatch，实际 %v", err)
+	}
+	if entries, _ := os.ReadDir(store); len(entries) != 0 {
+		t.Errorf("失败后不应留下下载文件: %v", entries)
+	}
+
+	pkg.URL = server.URL + "/missing"
+	if _, err := FromPackage(context.Background(), server.Client(), pkg, Options{Store: store}); err == nil {
+		t.Error("下载失败时应报错")
+	}
+}
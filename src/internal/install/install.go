@@ -0,0 +1,118 @@
+package install
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/config"
+	"github.com/whywhathow/jenv/internal/constants"
+	"github.com/whywhathow/jenv/internal/java"
+	"github.com/whywhathow/jenv/internal/verify"
+)
+
+// ErrNotJDK is returned when an archive does not contain a JDK
+var ErrNotJDK = errors.New("archive does not contain a JDK (no bin/javac found)")
+
+// Options control how an archive is installed
+type Options struct {
+	Name  string // 注册名称，默认由 release 文件生成，如 temurin-17.0.9+9
+	Store string // 安装目录，默认 ~/.jdks/store
+	// 解压前的校验，见 verify.Archive；为空时使用压缩包旁的 .sha256 和签名文件
+	SHA256    string
+	Signature string
+}
+
+// Result describes an installed JDK
+type Result struct {
+	Name   string
+	Home   string
+	Verify verify.Result // 解压前的校验结果，Verified 为 false 时没有检查任何内容
+}
+
+// StoreDir returns the directory jenv installs JDKs into, next to config.json
+func StoreDir() (string, error) {
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), constants.DEFAULT_STORE_DIR), nil
+}
+
+// FromArchive extracts a JDK archive into the store and registers it. The archive
+// is checked with verify.Archive and then unpacked into a temporary directory of
+// the store first, so a failed or refused archive leaves nothing behind.
+func FromArchive(archive string, opts Options) (Result, error) {
+	checked, err := verify.Archive(archive, verify.Options{
+		SHA256:    opts.SHA256,
+		Signature: opts.Signature,
+		Keys:      verify.Settings(),
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	store := opts.Store
+	if store == "" {
+		if store, err = StoreDir(); err != nil {
+			return Result{}, err
+		}
+	}
+	if err := os.MkdirAll(store, 0755); err != nil {
+		return Result{}, err
+	}
+
+	staging, err := os.MkdirTemp(store, ".extract-")
+	if err != nil {
+		return Result{}, err
+	}
+	defer os.RemoveAll(staging)
+
+	if err := Extract(archive, staging); err != nil {
+		return Result{}, err
+	}
+	home, err := javaHome(staging)
+	if err != nil {
+		return Result{}, err
+	}
+	if !config.ValidateJavaPath(home) {
+		return Result{}, fmt.Errorf("%s: %w", archive, ErrNotJDK)
+	}
+
+	name := opts.Name
+	if name == "" {
+		if name, err = java.NameFromRelease(home); err != nil {
+			return Result{}, fmt.Errorf("%v; use --name to choose a name", err)
+		}
+	}
+	if err := validName(name); err != nil {
+		return Result{}, err
+	}
+	if jdks, err := java.ListJdks(); err == nil {
+		if _, ok := jdks[name]; ok {
+			return Result{}, fmt.Errorf("%w: %s", config.ErrJDKExists, name)
+		}
+	}
+
+	dest := filepath.Join(store, name)
+	if _, err := os.Lstat(dest); err == nil {
+		return Result{}, fmt.Errorf("%s already exists", dest)
+	}
+	if err := os.Rename(home, dest); err != nil {
+		return Result{}, err
+	}
+	if err := java.AddJDK(name, dest); err != nil {
+		os.RemoveAll(dest)
+		return Result{}, err
+	}
+	return Result{Name: name, Home: dest, Verify: checked}, nil
+}
+
+// validName refuses names that cannot be used as a directory of the store
+func validName(name string) error {
+	if name == "." || name == ".." || strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\:*?"<>|`) {
+		return fmt.Errorf("invalid JDK name %q", name)
+	}
+	return nil
+}
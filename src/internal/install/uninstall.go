@@ -0,0 +1,250 @@
+package install
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/config"
+	"github.com/whywhathow/jenv/internal/java"
+)
+
+var (
+	// ErrNotOwned is returned when a JDK lives outside the directories jenv manages
+	ErrNotOwned = errors.New("not inside a jenv-owned directory")
+	// ErrCurrentJDK is returned when uninstalling the current JDK without a fallback
+	ErrCurrentJDK = errors.New("is the current JDK")
+)
+
+// UninstallOptions control how a JDK is uninstalled
+type UninstallOptions struct {
+	Fallback string   // 卸载当前 JDK 前切换到的 JDK 名称或选择器
+	Roots    []string // jenv 管理的目录，默认读取 config.json 中的 install.owned_roots
+}
+
+// UninstallResult describes an uninstalled JDK
+type UninstallResult struct {
+	Name      string
+	Dir       string // 被删除的目录
+	Reclaimed int64  // 释放的字节数
+	Fallback  string // 切换到的 JDK，未切换时为空
+}
+
+// OwnedRoots returns the directories jenv may delete JDKs from: install.owned_roots
+// from config.json, or the store
+func OwnedRoots() ([]string, error) {
+	if cfg, err := config.GetInstance(); err == nil && len(cfg.Install.OwnedRoots) > 0 {
+		roots := make([]string, 0, len(cfg.Install.OwnedRoots))
+		for _, root := range cfg.Install.OwnedRoots {
+			roots = append(roots, expandHome(root))
+		}
+		return roots, nil
+	}
+	store, err := StoreDir()
+	if err != nil {
+		return nil, err
+	}
+	return []string{store}, nil
+}
+
+// UninstallTarget returns the registered JDK called name and the directory
+// Uninstall would delete for it, without changing anything
+func UninstallTarget(name string, opts UninstallOptions) (config.JDK, string, error) {
+	jdks, err := java.ListJdks()
+	if err != nil {
+		return config.JDK{}, "", err
+	}
+	jdk, ok := jdks[name]
+	if !ok {
+		return jdk, "", fmt.Errorf("%w: %s", config.ErrJDKNotFound, name)
+	}
+
+	roots := opts.Roots
+	if len(roots) == 0 {
+		if roots, err = OwnedRoots(); err != nil {
+			return jdk, "", err
+		}
+	}
+	var others []string
+	for _, other := range jdks {
+		if other.Name != name {
+			others = append(others, other.Path)
+		}
+	}
+	if configPath, err := config.GetConfigPath(); err == nil {
+		others = append(others, configPath)
+	}
+	dir, err := ownedDir(jdk.Path, roots, others)
+	return jdk, dir, err
+}
+
+// Uninstall deletes the files of the registered JDK called name and unregisters it.
+// Only JDKs inside an owned root are deleted; use java.RemoveJDK for the others.
+// The current JDK is switched to opts.Fallback only after its directory has been
+// moved aside, and switched back when unregistering it fails.
+func Uninstall(name string, opts UninstallOptions) (UninstallResult, error) {
+	res := UninstallResult{Name: name}
+	_, dir, err := UninstallTarget(name, opts)
+	if err != nil {
+		return res, err
+	}
+	res.Dir = dir
+
+	// 当前 JDK 需要先切换到备用 JDK，否则拒绝；备用 JDK 在这里只解析，不切换
+	var fallback string
+	if current, err := java.GetCurrentJDK(); err == nil && current.Name == name {
+		if opts.Fallback == "" {
+			return res, fmt.Errorf("%s %w; pass a fallback JDK to switch to first", name, ErrCurrentJDK)
+		}
+		resolved, err := java.ResolveJDK(opts.Fallback)
+		if err != nil {
+			return res, fmt.Errorf("fallback: %w", err)
+		}
+		if resolved.JDK.Name == name {
+			return res, fmt.Errorf("fallback %s resolves to the JDK being uninstalled", opts.Fallback)
+		}
+		fallback = resolved.JDK.Name
+	}
+
+	if res.Reclaimed, err = dirSize(dir); err != nil {
+		return res, err
+	}
+
+	// 先把目录移到一边，切换或注销失败时还能恢复；删除发生在注销之后
+	trash, err := os.MkdirTemp(filepath.Dir(dir), ".uninstall-")
+	if err != nil {
+		return res, err
+	}
+	moved := filepath.Join(trash, filepath.Base(dir))
+	if err := os.Rename(dir, moved); err != nil {
+		os.Remove(trash)
+		return res, err
+	}
+	restore := func() {
+		os.Rename(moved, dir)
+		os.Remove(trash)
+	}
+
+	// 目录移走之后才切换，之前的任何失败都不会改变当前 JDK
+	if fallback != "" {
+		if err := java.UseJDK(fallback); err != nil {
+			restore()
+			return res, fmt.Errorf("switching to %s: %w", fallback, err)
+		}
+		res.Fallback = fallback
+	}
+	if err := java.RemoveJDK(name); err != nil {
+		restore()
+		if res.Fallback != "" {
+			// 目录已恢复，切换回原来的当前 JDK
+			java.UseJDK(name)
+			res.Fallback = ""
+		}
+		return res, err
+	}
+	if err := os.RemoveAll(trash); err != nil {
+		return res, fmt.Errorf("%s was unregistered but not fully deleted: %w", name, err)
+	}
+	return res, nil
+}
+
+// ownedDir returns the top-level directory of an owned root that holds the JDK at
+// path. Both the path as registered and its real path must be inside the same root,
+// so a symbolic link never leads the deletion out of it. The directory must not hold
+// another owned root or any of the paths in others.
+func ownedDir(path string, roots, others []string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	real, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return "", err
+	}
+
+	// 目录嵌套时以最深的根目录为准，例如 ~/.jdks 和 ~/.jdks/store
+	sorted := append([]string(nil), roots...)
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	for _, root := range sorted {
+		rootAbs, err := filepath.Abs(root)
+		if err != nil {
+			continue
+		}
+		rootReal, err := filepath.EvalSymlinks(rootAbs)
+		if err != nil {
+			continue
+		}
+		if _, ok := childOf(rootAbs, abs); !ok {
+			continue
+		}
+		first, ok := childOf(rootReal, real)
+		if !ok {
+			return "", fmt.Errorf("%w: %s resolves to %s, outside %s", ErrNotOwned, path, real, root)
+		}
+
+		dir := filepath.Join(rootReal, first)
+		info, err := os.Lstat(dir)
+		if err != nil {
+			return "", err
+		}
+		if !info.IsDir() {
+			return "", fmt.Errorf("%w: %s is not a directory", ErrNotOwned, dir)
+		}
+		for _, other := range append(others, roots...) {
+			otherAbs, err := filepath.Abs(other)
+			if err != nil {
+				continue
+			}
+			if otherReal, err := filepath.EvalSymlinks(otherAbs); err == nil {
+				otherAbs = otherReal
+			}
+			if otherAbs == dir || strings.HasPrefix(otherAbs, dir+string(filepath.Separator)) {
+				return "", fmt.Errorf("%s also holds %s; refusing to delete it", dir, other)
+			}
+		}
+		return dir, nil
+	}
+	return "", fmt.Errorf("%w: %s is outside %s", ErrNotOwned, path, strings.Join(roots, ", "))
+}
+
+// childOf returns the first path element of path below root
+func childOf(root, path string) (string, bool) {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(rel) {
+		return "", false
+	}
+	first, _, _ := strings.Cut(rel, string(filepath.Separator))
+	return first, true
+}
+
+// dirSize adds up the sizes of the files below dir without following symbolic links
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, `~\`) {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
@@ -0,0 +1,81 @@
+package install
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOwnedDir(t *testing.T) {
+	base := t.TempDir()
+	store := filepath.Join(base, "store")
+	outside := filepath.Join(base, "outside", "jdk")
+	for _, dir := range []string{
+		filepath.Join(store, "temurin-21", "bin"),
+		filepath.Join(store, "zulu-17", "Contents", "Home", "bin"),
+		filepath.Join(outside, "bin"),
+	} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(outside, filepath.Join(store, "escape")); err != nil {
+		t.Skip("无法创建符号链接:", err)
+	}
+	realStore, _ := filepath.EvalSymlinks(store)
+
+	tests := []struct {
+		path    string
+		roots   []string
+		want    string
+		wantErr error
+	}{
+		{path: filepath.Join(store, "temurin-21"), roots: []string{store}, want: filepath.Join(realStore, "temurin-21")},
+		// macOS 布局只注册了 Contents/Home，删除整个 JDK 目录
+		{path: filepath.Join(store, "zulu-17", "Contents", "Home"), roots: []string{store}, want: filepath.Join(realStore, "zulu-17")},
+		{path: outside, roots: []string{store}, wantErr: ErrNotOwned},
+		// 指向根目录外的符号链接不能让删除跑到外面
+		{path: filepath.Join(store, "escape"), roots: []string{store}, wantErr: ErrNotOwned},
+		{path: store, roots: []string{store}, wantErr: ErrNotOwned},
+		// 根目录嵌套时按最深的根目录划分
+		{path: filepath.Join(store, "temurin-21"), roots: []string{base, store}, want: filepath.Join(realStore, "temurin-21")},
+	}
+	for _, tt := range tests {
+		got, err := ownedDir(tt.path, tt.roots, nil)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ownedDir(%s) 期望错误 %v，实际 %q, %v", tt.path, tt.wantErr, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ownedDir(%s) = %q, %v，期望 %q", tt.path, got, err, tt.want)
+		}
+	}
+
+	// 同一目录下还有其他已注册的 JDK 时拒绝删除
+	other := filepath.Join(store, "zulu-17", "Contents", "Home")
+	if _, err := ownedDir(filepath.Join(store, "zulu-17", "Contents"), []string{store}, []string{other}); err == nil {
+		t.Error("目录中还有其他 JDK 时应拒绝删除")
+	}
+}
+
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "lib"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, size := range map[string]int{"bin-java": 100, "lib/modules": 1000} {
+		if err := os.WriteFile(filepath.Join(dir, name), make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 符号链接不计入大小，也不跟随
+	os.Symlink(filepath.Join(dir, "lib", "modules"), filepath.Join(dir, "link"))
+
+	size, err := dirSize(dir)
+	if err != nil || size != 1100 {
+		t.Errorf("dirSize = %d, %v，期望 1100", size, err)
+	}
+}
@@ -0,0 +1,89 @@
+package java
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/whywhathow/jenv/internal/config"
+	"github.com/whywhathow/jenv/internal/project"
+)
+
+// EnvVersion 环境变量可覆盖项目和全局设置，如 JENV_VERSION=17
+const EnvVersion = "JENV_VERSION"
+
+// Where the active JDK choice came from, highest precedence first
+const (
+	OriginEnv    = "env"
+	OriginFile   = "file"
+	OriginGlobal = "global"
+)
+
+// Active is the JDK in effect for a directory and what decided it
+type Active struct {
+	Resolution
+	Origin string // env、file 或 global
+	Source string // 环境变量名、版本文件位置或配置文件路径
+	Expr   string // 原始名称或选择器
+}
+
+// Home returns the Java home to put in JAVA_HOME for the active JDK. The global
+// JDK is reached through the symlink managed by 'jenv use', so later switches apply.
+func (a Active) Home() string {
+	if a.Origin == OriginGlobal && cfg != nil && cfg.SymlinkPath != "" {
+		if _, err := os.Stat(cfg.SymlinkPath); err == nil {
+			return cfg.SymlinkPath
+		}
+	}
+	return a.JDK.Path
+}
+
+// ResolveActive returns the JDK in effect for dir. JENV_VERSION wins over the
+// nearest version file (.java-version, or .tool-versions, .sdkmanrc and .jvmrc
+// of other tools), which wins over the global JDK set by 'jenv use'.
+func ResolveActive(dir string, getenv func(string) string) (Active, error) {
+	configPath, _ := config.GetConfigPath()
+	return resolveActiveIn(dir, getenv, cfg.Jdks, cfg.Current, configPath)
+}
+
+func resolveActiveIn(dir string, getenv func(string) string, jdks map[string]config.JDK, current, configPath string) (Active, error) {
+	if expr := getenv(EnvVersion); expr != "" {
+		return resolveActiveExpr(Active{Origin: OriginEnv, Source: EnvVersion, Expr: expr}, jdks)
+	}
+
+	pin, err := project.FindPin(dir)
+	if err == nil {
+		active := Active{Origin: OriginFile, Source: pin.String(), Expr: pin.Selector}
+		expr, err := PinSelector(pin)
+		if err != nil {
+			return active, fmt.Errorf("%s: %w", active.Source, err)
+		}
+		if expr != pin.Selector {
+			// asdf、SDKMAN!、jabba 的标识符：错误信息中同时给出原文和转换后的选择器
+			res, err := resolveIn(expr, jdks)
+			active.Resolution = res
+			if err != nil {
+				return active, fmt.Errorf("%s: java %s: %w", active.Source, pin.Selector, err)
+			}
+			return active, nil
+		}
+		return resolveActiveExpr(active, jdks)
+	}
+	if !errors.Is(err, project.ErrNoPin) {
+		return Active{Origin: OriginFile}, err
+	}
+
+	if current == "" {
+		return Active{Origin: OriginGlobal, Source: configPath}, ErrNoJDKConfigured
+	}
+	return resolveActiveExpr(Active{Origin: OriginGlobal, Source: configPath, Expr: current}, jdks)
+}
+
+func resolveActiveExpr(active Active, jdks map[string]config.JDK) (Active, error) {
+	res, err := resolveIn(active.Expr, jdks)
+	active.Resolution = res
+	if err != nil {
+		return active, fmt.Errorf("%s: %w", active.Source, err)
+	}
+	return active, nil
+}
@@ -0,0 +1,65 @@
+package java
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/whywhathow/jenv/internal/project"
+)
+
+func TestResolveActivePrecedence(t *testing.T) {
+	dir := t.TempDir()
+	env := map[string]string{}
+	getenv := func(key string) string { return env[key] }
+
+	// 只有全局设置
+	active, err := resolveActiveIn(dir, getenv, testJDKs(), "jdk11", "config.json")
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if active.Origin != OriginGlobal || active.JDK.Name != "jdk11" {
+		t.Errorf("期望全局 jdk11，实际 %s %s", active.Origin, active.JDK.Name)
+	}
+
+	// .java-version 优先于全局设置
+	path, err := project.WriteVersionFile(dir, "17")
+	if err != nil {
+		t.Fatalf("写入失败: %v", err)
+	}
+	active, err = resolveActiveIn(filepath.Join(dir, "sub"), getenv, testJDKs(), "jdk11", "config.json")
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if active.Origin != OriginFile || active.JDK.Name != "jdk17" || active.Source != path+":1" {
+		t.Errorf("期望文件 jdk17，实际 %+v", active)
+	}
+
+	// JENV_VERSION 优先于一切
+	env[EnvVersion] = "zulu"
+	active, err = resolveActiveIn(dir, getenv, testJDKs(), "jdk11", "config.json")
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if active.Origin != OriginEnv || active.JDK.Name != "jdk21-zul" {
+		t.Errorf("期望环境变量 jdk21-zul，实际 %s %s", active.Origin, active.JDK.Name)
+	}
+}
+
+func TestResolveActiveErrors(t *testing.T) {
+	dir := t.TempDir()
+	getenv := func(string) string { return "" }
+
+	if _, err := resolveActiveIn(dir, getenv, testJDKs(), "", "config.json"); !errors.Is(err, ErrNoJDKConfigured) {
+		t.Errorf("期望 ErrNoJDKConfigured，实际 %v", err)
+	}
+
+	path, _ := project.WriteVersionFile(dir, "16")
+	active, err := resolveActiveIn(dir, getenv, testJDKs(), "jdk11", "config.json")
+	if err == nil {
+		t.Fatal("期望未匹配的 .java-version 报错")
+	}
+	if active.Origin != OriginFile || active.Source != path+":1" {
+		t.Errorf("错误应指明来源文件，实际 %+v", active)
+	}
+}
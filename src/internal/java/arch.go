@@ -0,0 +1,174 @@
+package java
+
+import (
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+// ArchUnknown is recorded when neither bin/java nor the release file reveal the architecture
+const ArchUnknown = "unknown"
+
+// ErrArchMismatch is returned when a JDK is built for a CPU architecture this machine cannot run
+var ErrArchMismatch = errors.New("JDK architecture does not match this machine")
+
+// DetectArch returns the GOARCH-style CPU architecture of the Java home.
+// The executable header of bin/java is authoritative; OS_ARCH from the release file is the fallback.
+func DetectArch(home string) string {
+	if arch, err := binaryArch(javaExecutable(home)); err == nil && arch != "" {
+		return arch
+	}
+	if rel, err := ReadRelease(home); err == nil {
+		if arch := NormalizeArch(rel.OSArch); arch != "" {
+			return arch
+		}
+	}
+	return ArchUnknown
+}
+
+// javaExecutable returns the path of the java launcher inside a Java home
+func javaExecutable(home string) string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(home, "bin", "java.exe")
+	}
+	return filepath.Join(home, "bin", "java")
+}
+
+// binaryArch reads the machine type of an ELF, Mach-O or PE executable
+func binaryArch(path string) (string, error) {
+	if f, err := elf.Open(path); err == nil {
+		defer f.Close()
+		return elfArch(f), nil
+	}
+	if f, err := macho.Open(path); err == nil {
+		defer f.Close()
+		return machoArch(f.Cpu), nil
+	}
+	if fat, err := macho.OpenFat(path); err == nil {
+		defer fat.Close()
+		// 通用二进制：包含本机架构时视为本机架构
+		var first string
+		for _, a := range fat.Arches {
+			arch := machoArch(a.Cpu)
+			if arch == runtime.GOARCH {
+				return arch, nil
+			}
+			if first == "" {
+				first = arch
+			}
+		}
+		return first, nil
+	}
+	if f, err := pe.Open(path); err == nil {
+		defer f.Close()
+		return peArch(f.Machine), nil
+	}
+	return "", fmt.Errorf("unrecognized executable format: %s", path)
+}
+
+func elfArch(f *elf.File) string {
+	switch f.Machine {
+	case elf.EM_X86_64:
+		return "amd64"
+	case elf.EM_AARCH64:
+		return "arm64"
+	case elf.EM_386:
+		return "386"
+	case elf.EM_ARM:
+		return "arm"
+	case elf.EM_PPC64:
+		if f.ByteOrder == binary.LittleEndian {
+			return "ppc64le"
+		}
+		return "ppc64"
+	case elf.EM_S390:
+		return "s390x"
+	case elf.EM_RISCV:
+		return "riscv64"
+	case elf.EM_LOONGARCH:
+		return "loong64"
+	}
+	return strings.ToLower(strings.TrimPrefix(f.Machine.String(), "EM_"))
+}
+
+func machoArch(c macho.Cpu) string {
+	switch c {
+	case macho.CpuAmd64:
+		return "amd64"
+	case macho.CpuArm64:
+		return "arm64"
+	case macho.Cpu386:
+		return "386"
+	}
+	return strings.ToLower(c.String())
+}
+
+func peArch(m uint16) string {
+	switch m {
+	case pe.IMAGE_FILE_MACHINE_AMD64:
+		return "amd64"
+	case pe.IMAGE_FILE_MACHINE_ARM64:
+		return "arm64"
+	case pe.IMAGE_FILE_MACHINE_I386:
+		return "386"
+	}
+	return fmt.Sprintf("pe-0x%x", m)
+}
+
+// NormalizeArch maps the names used by release files, vendors and users
+// (x86_64, x64, aarch64, i686, ...) onto GOARCH names
+func NormalizeArch(name string) string {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "":
+		return ""
+	case "amd64", "x86_64", "x64", "x86-64":
+		return "amd64"
+	case "arm64", "aarch64":
+		return "arm64"
+	case "386", "x86", "i386", "i486", "i586", "i686":
+		return "386"
+	case "arm", "aarch32", "armv7", "armv7l", "arm32":
+		return "arm"
+	case "ppc64le", "ppc64el":
+		return "ppc64le"
+	case "s390x":
+		return "s390x"
+	case "riscv64":
+		return "riscv64"
+	default:
+		return strings.ToLower(strings.TrimSpace(name))
+	}
+}
+
+// archEmulated reports whether the host runs binaries of arch through emulation
+// (Rosetta 2 on macOS, x64 emulation on Windows on ARM)
+func archEmulated(arch string) bool {
+	return runtime.GOARCH == "arm64" && arch == "amd64" &&
+		(runtime.GOOS == "darwin" || runtime.GOOS == "windows")
+}
+
+// IsNativeArch reports whether a JDK of arch runs natively on this machine.
+// An unknown architecture is given the benefit of the doubt.
+func IsNativeArch(arch string) bool {
+	return arch == "" || arch == ArchUnknown || arch == runtime.GOARCH
+}
+
+// CheckArch returns ErrArchMismatch when jdk cannot run on this machine.
+// JDKs that only run through emulation are reported by the returned warning.
+func CheckArch(jdk config.JDK) (warning string, err error) {
+	if IsNativeArch(jdk.Arch) {
+		return "", nil
+	}
+	if archEmulated(jdk.Arch) {
+		return fmt.Sprintf("%s is an %s JDK and will run under emulation on this %s machine", jdk.Name, jdk.Arch, runtime.GOARCH), nil
+	}
+	return "", fmt.Errorf("%w: %s is built for %s, this machine is %s", ErrArchMismatch, jdk.Name, jdk.Arch, runtime.GOARCH)
+}
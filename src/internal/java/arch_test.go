@@ -0,0 +1,120 @@
+package java
+
+import (
+	"debug/elf"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+// writeELF writes a minimal 64-bit little-endian ELF header for the given machine
+func writeELF(t *testing.T, path string, machine elf.Machine) {
+	t.Helper()
+	header := make([]byte, 64)
+	copy(header, elf.ELFMAG)
+	header[elf.EI_CLASS] = byte(elf.ELFCLASS64)
+	header[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	header[elf.EI_VERSION] = byte(elf.EV_CURRENT)
+	binary.LittleEndian.PutUint16(header[16:], uint16(elf.ET_EXEC))
+	binary.LittleEndian.PutUint16(header[18:], uint16(machine))
+	binary.LittleEndian.PutUint32(header[20:], uint32(elf.EV_CURRENT))
+	binary.LittleEndian.PutUint16(header[52:], 64) // e_ehsize
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("创建目录失败: %v", err)
+	}
+	if err := os.WriteFile(path, header, 0755); err != nil {
+		t.Fatalf("写入 ELF 文件失败: %v", err)
+	}
+}
+
+func TestDetectArch(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("bin/java.exe 为 PE 格式")
+	}
+
+	tests := []struct {
+		name     string
+		machine  elf.Machine
+		osArch   string
+		expected string
+	}{
+		{name: "x86_64", machine: elf.EM_X86_64, expected: "amd64"},
+		{name: "aarch64", machine: elf.EM_AARCH64, expected: "arm64"},
+		{name: "ELF 优先于 release", machine: elf.EM_AARCH64, osArch: "x86_64", expected: "arm64"},
+		{name: "仅 release", osArch: "aarch64", expected: "arm64"},
+		{name: "无信息", expected: ArchUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			if tt.machine != 0 {
+				writeELF(t, filepath.Join(home, "bin", "java"), tt.machine)
+			}
+			if tt.osArch != "" {
+				writeRelease(t, home, "OS_ARCH=\""+tt.osArch+"\"\n")
+			}
+			if got := DetectArch(home); got != tt.expected {
+				t.Errorf("期望 %s，实际 %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeArch(t *testing.T) {
+	tests := map[string]string{
+		"x86_64":  "amd64",
+		"x64":     "amd64",
+		"aarch64": "arm64",
+		"i686":    "386",
+		"ppc64le": "ppc64le",
+		"":        "",
+	}
+	for input, expected := range tests {
+		if got := NormalizeArch(input); got != expected {
+			t.Errorf("NormalizeArch(%q) = %q，期望 %q", input, got, expected)
+		}
+	}
+}
+
+func TestCheckArch(t *testing.T) {
+	native := config.JDK{Name: "native", Arch: runtime.GOARCH}
+	if _, err := CheckArch(native); err != nil {
+		t.Errorf("本机架构不应报错: %v", err)
+	}
+	if _, err := CheckArch(config.JDK{Name: "unknown", Arch: ArchUnknown}); err != nil {
+		t.Errorf("未知架构不应报错: %v", err)
+	}
+
+	foreign := config.JDK{Name: "foreign", Arch: "s390x"}
+	if runtime.GOARCH == "s390x" {
+		foreign.Arch = "riscv64"
+	}
+	if _, err := CheckArch(foreign); !errors.Is(err, ErrArchMismatch) {
+		t.Errorf("期望 ErrArchMismatch，实际 %v", err)
+	}
+}
+
+func TestResolvePrefersNativeArch(t *testing.T) {
+	foreign := "s390x"
+	if runtime.GOARCH == foreign {
+		foreign = "riscv64"
+	}
+	jdks := map[string]config.JDK{
+		"a-cross":  {Name: "a-cross", JavaRuntimeVersion: "17.0.10+7", Arch: foreign, CrossTarget: true},
+		"b-native": {Name: "b-native", JavaRuntimeVersion: "17.0.9+9", Arch: runtime.GOARCH},
+	}
+	res, err := resolveIn("17", jdks)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if res.JDK.Name != "b-native" {
+		t.Errorf("期望优先选择本机架构，实际 %s", res.JDK.Name)
+	}
+}
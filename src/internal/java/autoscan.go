@@ -0,0 +1,135 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RootScan is the outcome of scanning one of several roots
+type RootScan struct {
+	Root   string
+	Result ScanResult
+}
+
+// AutoScanRoots returns the well-known JDK locations of this machine that exist
+func AutoScanRoots() []string {
+	home, _ := os.UserHomeDir()
+	var roots []string
+	for _, root := range autoScanRoots(runtime.GOOS, home, os.Getenv) {
+		if info, err := os.Stat(root); err == nil && info.IsDir() {
+			roots = append(roots, root)
+		}
+	}
+	return roots
+}
+
+// autoScanRoots lists where JDKs are usually installed on goos, by package managers,
+// vendor installers and version managers
+func autoScanRoots(goos, home string, getenv func(string) string) []string {
+	join := filepath.Join
+	var roots []string
+	switch goos {
+	case "windows":
+		for _, key := range []string{"ProgramFiles", "ProgramFiles(x86)", "ProgramW6432"} {
+			dir := getenv(key)
+			if dir == "" {
+				continue
+			}
+			for _, vendor := range []string{"Java", "Eclipse Adoptium", "Eclipse Foundation", "AdoptOpenJDK",
+				"Zulu", "Amazon Corretto", "Microsoft", "BellSoft", "Semeru", "GraalVM"} {
+				roots = append(roots, join(dir, vendor))
+			}
+		}
+		roots = append(roots, join(home, "scoop", "apps"))
+	case "darwin":
+		roots = append(roots,
+			"/Library/Java/JavaVirtualMachines",
+			join(home, "Library", "Java", "JavaVirtualMachines"),
+			"/opt/homebrew/opt",
+			"/usr/local/opt")
+	default:
+		roots = append(roots,
+			"/usr/lib/jvm",
+			"/usr/lib64/jvm",
+			"/usr/java",
+			"/usr/local/java",
+			"/opt",
+			"/home/linuxbrew/.linuxbrew/opt")
+	}
+
+	// 各平台通用的版本管理工具目录
+	roots = append(roots,
+		join(home, ".jdks"),
+		join(envOr(getenv, "SDKMAN_DIR", join(home, ".sdkman")), "candidates", "java"),
+		join(envOr(getenv, "ASDF_DATA_DIR", join(home, ".asdf")), "installs", "java"),
+		join(envOr(getenv, "JABBA_HOME", join(home, ".jabba")), "jdk"))
+
+	// 去掉重复的目录，例如 ProgramFiles 和 ProgramW6432 通常相同
+	seen := make(map[string]bool)
+	unique := roots[:0]
+	for _, root := range roots {
+		key := strings.ToLower(filepath.Clean(root))
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, root)
+		}
+	}
+	return unique
+}
+
+// ScanRoots scans every root concurrently and returns the statistics of each root
+// together with one merged result. A JDK reachable from several roots, directly or
+// through symbolic links, is listed once, for the first root that found it.
+func ScanRoots(roots []string, opts ScanOptions) ([]RootScan, ScanResult) {
+	start := time.Now()
+	scans := make([]RootScan, len(roots))
+	var wg sync.WaitGroup
+	for i, root := range roots {
+		wg.Add(1)
+		go func(i int, root string) {
+			defer wg.Done()
+			scans[i] = RootScan{Root: root, Result: scanDir(root, opts)}
+		}(i, root)
+	}
+	wg.Wait()
+
+	results := make([]ScanResult, len(scans))
+	for i, scan := range scans {
+		results[i] = scan.Result
+	}
+	merged := MergeScanResults(results...)
+	merged.Duration = time.Since(start)
+	return scans, merged
+}
+
+// MergeScanResults combines scan results, keeping the first hit for each real path
+// and adding up the statistics
+func MergeScanResults(results ...ScanResult) ScanResult {
+	var merged ScanResult
+	seen := make(map[string]bool)
+	for _, result := range results {
+		for _, jdk := range result.JDKs {
+			key := jdk.Path
+			if real, err := filepath.EvalSymlinks(jdk.Path); err == nil {
+				key = real
+			}
+			if runtime.GOOS == "windows" {
+				key = strings.ToLower(key)
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged.JDKs = append(merged.JDKs, jdk)
+		}
+		merged.Duration += result.Duration
+		merged.Scanned += result.Scanned
+		merged.Skipped += result.Skipped
+		merged.Excluded += result.Excluded
+	}
+	return merged
+}
@@ -0,0 +1,67 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAutoScanRoots(t *testing.T) {
+	env := map[string]string{
+		"ProgramFiles": `C:\Program Files`,
+		"ProgramW6432": `C:\Program Files`,
+		"SDKMAN_DIR":   "/custom/sdkman",
+	}
+	getenv := func(key string) string { return env[key] }
+
+	tests := []struct {
+		goos string
+		want []string
+	}{
+		{"linux", []string{"/usr/lib/jvm", "/opt", filepath.Join("/home/u", ".jdks"), filepath.Join("/custom/sdkman", "candidates", "java")}},
+		{"darwin", []string{"/Library/Java/JavaVirtualMachines", filepath.Join("/home/u", "Library", "Java", "JavaVirtualMachines")}},
+		{"windows", []string{filepath.Join(`C:\Program Files`, "Eclipse Adoptium")}},
+	}
+	for _, tt := range tests {
+		roots := autoScanRoots(tt.goos, "/home/u", getenv)
+		set := make(map[string]int)
+		for _, root := range roots {
+			set[root]++
+		}
+		for _, want := range tt.want {
+			if set[want] != 1 {
+				t.Errorf("%s: 期望包含一次 %s，实际 %v", tt.goos, want, roots)
+			}
+		}
+	}
+}
+
+func TestScanRoots(t *testing.T) {
+	base := t.TempDir()
+	makeRuntime(t, filepath.Join(base, "a", "jdk-17"), true)
+	makeRuntime(t, filepath.Join(base, "b", "jdk-21"), true)
+	// c 是指向 a 的符号链接，其中的 JDK 只应出现一次
+	if err := os.Symlink(filepath.Join(base, "a"), filepath.Join(base, "c")); err != nil {
+		t.Skip("无法创建符号链接:", err)
+	}
+	roots := []string{filepath.Join(base, "a"), filepath.Join(base, "b"), filepath.Join(base, "c")}
+
+	scans, merged := ScanRoots(roots, ScanOptions{})
+	if len(scans) != 3 {
+		t.Fatalf("每个目录都应有统计，实际 %d", len(scans))
+	}
+	for i, scan := range scans {
+		if scan.Root != roots[i] || len(scan.Result.JDKs) != 1 {
+			t.Errorf("%s: 期望找到 1 个 JDK，实际 %+v", scan.Root, scan.Result)
+		}
+	}
+	if len(merged.JDKs) != 2 {
+		t.Fatalf("合并后应去重为 2 个 JDK，实际 %+v", merged.JDKs)
+	}
+	if merged.JDKs[0].Path != filepath.Join(base, "a", "jdk-17") {
+		t.Errorf("重复的 JDK 应保留先扫描到的路径，实际 %s", merged.JDKs[0].Path)
+	}
+	if merged.Scanned != scans[0].Result.Scanned+scans[1].Result.Scanned+scans[2].Result.Scanned {
+		t.Errorf("合并后的统计应为各目录之和: %+v", merged)
+	}
+}
@@ -0,0 +1,69 @@
+package java
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/config"
+	"github.com/whywhathow/jenv/internal/project"
+)
+
+// ResolveRequirement picks the registered JDK that best fits the Java level a build
+// file asks for: a JDK of exactly that version, of the requested distribution when
+// one is named, otherwise the oldest newer JDK. Toolchains (req.Exact) only accept
+// that version. JREs and runtime images are skipped since builds need a compiler.
+func ResolveRequirement(req project.Requirement) (Resolution, error) {
+	return resolveRequirementIn(req, cfg.Jdks)
+}
+
+func resolveRequirementIn(req project.Requirement, jdks map[string]config.JDK) (Resolution, error) {
+	compilers := make(map[string]config.JDK, len(jdks))
+	for name, jdk := range jdks {
+		if jdk.HasCompiler() && !jdk.CrossTarget {
+			compilers[name] = jdk
+		}
+	}
+
+	level := strconv.Itoa(req.Level)
+	vendor := NormalizeVendor(strings.ReplaceAll(req.Vendor, "_", ""))
+	// 找不到指定发行版时使用其他发行版，并在原因中说明
+	vendorNote := ""
+	if vendor != "" {
+		if res, err := resolveIn(vendor+"@"+level, compilers); err == nil {
+			return res, nil
+		}
+		vendorNote = fmt.Sprintf("no %s JDK of Java %d is registered; ", vendor, req.Level)
+	}
+	if res, err := resolveIn(level, compilers); err == nil {
+		res.Reason = vendorNote + res.Reason
+		return res, nil
+	}
+
+	// 工具链只接受该版本，不能退而使用更新的 JDK
+	if req.Exact {
+		return Resolution{}, fmt.Errorf("%w: %s requires exactly Java %d, a toolchain does not accept newer versions, and none is registered",
+			config.ErrJDKNotFound, req, req.Level)
+	}
+
+	res, err := resolveIn(">="+level, compilers)
+	if err != nil {
+		if errors.Is(err, config.ErrJDKNotFound) {
+			return res, fmt.Errorf("%w: %s requires Java %d and no registered JDK is that new", config.ErrJDKNotFound, req, req.Level)
+		}
+		return res, err
+	}
+	// 候选按版本从高到低排列，取满足要求的最低特性版本
+	lowest := res.Candidates[0]
+	lowestFeature := 0
+	for _, jdk := range res.Candidates {
+		v, _ := JDKVersion(jdk)
+		if lowestFeature == 0 || v.Feature() < lowestFeature {
+			lowest, lowestFeature = jdk, v.Feature()
+		}
+	}
+	res.JDK = lowest
+	res.Reason = fmt.Sprintf("%sno Java %d JDK is registered; picked %s, the oldest newer JDK (Java %d)", vendorNote, req.Level, lowest.Name, lowestFeature)
+	return res, nil
+}
@@ -0,0 +1,62 @@
+package java
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/whywhathow/jenv/internal/config"
+	"github.com/whywhathow/jenv/internal/project"
+)
+
+func TestResolveRequirement(t *testing.T) {
+	jdks := testJDKs()
+	tests := []struct {
+		req      project.Requirement
+		expected string
+	}{
+		{project.Requirement{Level: 17}, "jdk17"},
+		{project.Requirement{Level: 21, Vendor: "AZUL"}, "jdk21-zul"},
+		{project.Requirement{Level: 21, Vendor: "ORACLE"}, "jdk21"},
+		{project.Requirement{Level: 12}, "jdk17"},
+		{project.Requirement{Level: 9}, "jdk11"},
+	}
+	for _, tt := range tests {
+		res, err := resolveRequirementIn(tt.req, jdks)
+		if err != nil || res.JDK.Name != tt.expected {
+			t.Errorf("%+v: 期望 %s，实际 %s (%v)", tt.req, tt.expected, res.JDK.Name, err)
+		}
+	}
+	if _, err := resolveRequirementIn(project.Requirement{Level: 25}, jdks); err == nil {
+		t.Error("没有足够新的 JDK 时应报错")
+	}
+}
+
+func TestResolveRequirementExact(t *testing.T) {
+	jdks := testJDKs()
+	// 工具链要求 Java 12 时不能使用 Java 17
+	req := project.Requirement{Level: 12, Exact: true, File: "build.gradle.kts", Line: 7}
+	_, err := resolveRequirementIn(req, jdks)
+	if !errors.Is(err, config.ErrJDKNotFound) || !strings.Contains(err.Error(), "build.gradle.kts:7") {
+		t.Errorf("期望 ErrJDKNotFound 并指出文件位置，实际 %v", err)
+	}
+	req.Level = 17
+	if res, err := resolveRequirementIn(req, jdks); err != nil || res.JDK.Name != "jdk17" {
+		t.Errorf("期望 jdk17，实际 %s (%v)", res.JDK.Name, err)
+	}
+}
+
+func TestResolveRequirementVendorUnmet(t *testing.T) {
+	// 没有指定发行版的 JDK 时使用其他发行版，原因中需要说明
+	res, err := resolveRequirementIn(project.Requirement{Level: 11, Vendor: "ORACLE"}, testJDKs())
+	if err != nil || res.JDK.Name != "jdk11" {
+		t.Fatalf("期望 jdk11，实际 %s (%v)", res.JDK.Name, err)
+	}
+	if !strings.Contains(res.Reason, "no oracle JDK of Java 11") {
+		t.Errorf("原因中缺少发行版说明: %s", res.Reason)
+	}
+	res, err = resolveRequirementIn(project.Requirement{Level: 12, Vendor: "ORACLE"}, testJDKs())
+	if err != nil || !strings.Contains(res.Reason, "no oracle JDK of Java 12") {
+		t.Errorf("更新版本的回退也应说明发行版: %s (%v)", res.Reason, err)
+	}
+}
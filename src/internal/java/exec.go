@@ -0,0 +1,132 @@
+package java
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/config"
+	"github.com/whywhathow/jenv/internal/shell"
+	"github.com/whywhathow/jenv/internal/sys"
+)
+
+// ExecEnv builds the environment for a command run under jdk: the inherited
+// environment with the variables from JDKVars replaced.
+func ExecEnv(environ []string, jdk config.JDK) []string {
+	vars := JDKVars(environ, jdk)
+	env := make([]string, 0, len(environ)+len(vars))
+	for _, kv := range environ {
+		key, _, _ := strings.Cut(kv, "=")
+		if !envKeyIs(key, "PATH") && !envKeyIs(key, "JAVA_HOME") && !envKeyIs(key, EnvVersion) {
+			env = append(env, kv)
+		}
+	}
+	for _, v := range vars {
+		env = append(env, v.Key+"="+v.Value)
+	}
+	return env
+}
+
+// JDKVars returns the variables that make jdk the active JDK: those of JavaHomeVars
+// plus JENV_VERSION, so shims pick the same JDK.
+func JDKVars(environ []string, jdk config.JDK) []shell.EnvVar {
+	return append(JavaHomeVars(environ, jdk.Path), shell.EnvVar{Key: EnvVersion, Value: jdk.Name})
+}
+
+// JavaHomeVars returns JAVA_HOME pointing at home and a PATH with home's bin/ first.
+// The bin/ directories of other JDKs are removed from the inherited PATH so they
+// cannot shadow it.
+func JavaHomeVars(environ []string, home string) []shell.EnvVar {
+	var oldPath, oldJavaHome string
+	for _, kv := range environ {
+		key, value, _ := strings.Cut(kv, "=")
+		switch {
+		case envKeyIs(key, "PATH"):
+			oldPath = value
+		case envKeyIs(key, "JAVA_HOME"):
+			oldJavaHome = value
+		}
+	}
+
+	jdkBins := otherJDKBins(oldJavaHome)
+	entries := []string{filepath.Join(home, "bin")}
+	for _, entry := range filepath.SplitList(oldPath) {
+		if entry == "" || isJDKBin(entry, jdkBins) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return []shell.EnvVar{
+		{Key: "JAVA_HOME", Value: home},
+		{Key: "PATH", Value: strings.Join(entries, string(os.PathListSeparator))},
+	}
+}
+
+// ExecCommand runs args[0] under jdk with the environment from ExecEnv.
+// On Unix jenv is replaced by the command, so signals and the exit code pass through
+// untouched; on Windows jenv waits for the command and exits with its code.
+func ExecCommand(jdk config.JDK, args []string) error {
+	env := ExecEnv(os.Environ(), jdk)
+
+	// 按子进程的 PATH 查找命令
+	for _, kv := range env {
+		if key, value, _ := strings.Cut(kv, "="); key == "PATH" {
+			os.Setenv("PATH", value)
+		}
+	}
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		return err
+	}
+	return sys.Exec(path, args, env)
+}
+
+// otherJDKBins collects the bin/ directories of every Java home jenv knows about
+func otherJDKBins(javaHome string) map[string]bool {
+	bins := make(map[string]bool)
+	add := func(home string) {
+		if home != "" {
+			bins[cleanPathKey(filepath.Join(home, "bin"))] = true
+		}
+	}
+	add(javaHome)
+	if cfg != nil {
+		add(cfg.SymlinkPath)
+		for _, jdk := range cfg.Jdks {
+			add(jdk.Path)
+		}
+	}
+	return bins
+}
+
+// isJDKBin reports whether a PATH entry is the bin/ directory of a Java home
+func isJDKBin(entry string, known map[string]bool) bool {
+	if known[cleanPathKey(entry)] {
+		return true
+	}
+	home := filepath.Dir(filepath.Clean(entry))
+	if filepath.Base(filepath.Clean(entry)) != "bin" {
+		return false
+	}
+	return config.ValidateJavaRuntimePath(home) &&
+		(regularFile(filepath.Join(home, "release")) || regularFile(filepath.Join(home, "lib", "rt.jar")))
+}
+
+func cleanPathKey(path string) string {
+	path = filepath.Clean(path)
+	if runtime.GOOS == "windows" {
+		return strings.ToLower(path)
+	}
+	return path
+}
+
+// envKeyIs compares environment variable names; Windows ignores case (Path vs PATH)
+func envKeyIs(key, name string) bool {
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(key, name)
+	}
+	return key == name
+}
@@ -0,0 +1,56 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+func TestExecEnv(t *testing.T) {
+	root := t.TempDir()
+	target := filepath.Join(root, "jdk-8")
+	other := filepath.Join(root, "jdk-21")
+	makeRuntime(t, other, true)
+	writeRelease(t, other, "JAVA_VERSION=\"21\"\n")
+	inherited := filepath.Join(root, "inherited-jdk")
+
+	sep := string(os.PathListSeparator)
+	environ := []string{
+		"HOME=/home/dev",
+		"JAVA_HOME=" + inherited,
+		"JENV_VERSION=21",
+		"PATH=" + strings.Join([]string{
+			filepath.Join(inherited, "bin"),
+			"/usr/local/bin",
+			filepath.Join(other, "bin"),
+			"/usr/bin",
+		}, sep),
+	}
+
+	env := ExecEnv(environ, config.JDK{Name: "jdk8", Path: target})
+	values := map[string]string{}
+	for _, kv := range env {
+		key, value, _ := strings.Cut(kv, "=")
+		if _, dup := values[key]; dup {
+			t.Errorf("环境变量重复: %s", key)
+		}
+		values[key] = value
+	}
+
+	if values["JAVA_HOME"] != target {
+		t.Errorf("JAVA_HOME 错误: %s", values["JAVA_HOME"])
+	}
+	if values[EnvVersion] != "jdk8" {
+		t.Errorf("JENV_VERSION 错误: %s", values[EnvVersion])
+	}
+	if values["HOME"] != "/home/dev" {
+		t.Error("其他环境变量应保留")
+	}
+	expected := strings.Join([]string{filepath.Join(target, "bin"), "/usr/local/bin", "/usr/bin"}, sep)
+	if values["PATH"] != expected {
+		t.Errorf("PATH 错误:\n期望 %s\n实际 %s", expected, values["PATH"])
+	}
+}
@@ -0,0 +1,210 @@
+package java
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+// 可以导入 JDK 的其他版本管理工具
+const (
+	ImportSDKMAN      = "sdkman"
+	ImportAsdf        = "asdf"
+	ImportJabba       = "jabba"
+	ImportIntelliJ    = "intellij"
+	ImportJenvClassic = "jenv-classic"
+)
+
+// ImportSources lists the tools 'jenv import --from' understands
+var ImportSources = []string{ImportSDKMAN, ImportAsdf, ImportJabba, ImportIntelliJ, ImportJenvClassic}
+
+// ErrUnknownImportSource is returned for a tool jenv cannot import from
+var ErrUnknownImportSource = errors.New("unknown import source")
+
+// ImportCandidate is a JDK installed by another tool
+type ImportCandidate struct {
+	ID         string // 工具中的标识，如 17.0.9-tem 或 zulu@1.17.0
+	Name       string // 建议的注册名称
+	Path       string // Java home
+	Source     string
+	Registered bool // 路径已经注册过
+}
+
+// FindImports lists the JDKs another tool has installed, marking the ones already
+// registered with jenv the same way scans exclude them
+func FindImports(source string) ([]ImportCandidate, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := findImportsIn(source, home, os.Getenv)
+	if err != nil {
+		return nil, err
+	}
+	existing := getExistingJDKPaths()
+	jdks, _ := ListJdks()
+	for i, c := range candidates {
+		candidates[i].Registered = isPathExcluded(c.Path, existing)
+		if _, taken := jdks[c.Name]; taken {
+			candidates[i].Name = importedName(c)
+		}
+	}
+	return candidates, nil
+}
+
+// ImportJDK registers a candidate through AddJDK and returns the name used. When the
+// suggested name is taken it is prefixed with the tool, e.g. sdkman-17.0.9-tem.
+func ImportJDK(c ImportCandidate) (string, error) {
+	err := AddJDK(c.Name, c.Path)
+	if !errors.Is(err, config.ErrJDKExists) {
+		return c.Name, err
+	}
+	name := importedName(c)
+	return name, AddJDK(name, c.Path)
+}
+
+func importedName(c ImportCandidate) string {
+	if strings.HasPrefix(c.Name, c.Source+"-") {
+		return c.Name
+	}
+	return c.Source + "-" + c.Name
+}
+
+// findImportsIn discovers the JDKs of source below the user's home directory
+func findImportsIn(source, home string, getenv func(string) string) ([]ImportCandidate, error) {
+	var candidates []ImportCandidate
+	switch source {
+	case ImportSDKMAN:
+		// ~/.sdkman/candidates/java/<id>，current 为指向默认版本的符号链接
+		root := envOr(getenv, "SDKMAN_DIR", filepath.Join(home, ".sdkman"))
+		candidates = importDir(source, filepath.Join(root, "candidates", "java"), nil)
+	case ImportAsdf:
+		root := envOr(getenv, "ASDF_DATA_DIR", filepath.Join(home, ".asdf"))
+		candidates = importDir(source, filepath.Join(root, "installs", "java"), nil)
+	case ImportJabba:
+		// jabba 的标识形如 zulu@1.17.0，'@' 在 jenv 中表示选择器，注册名称换成 '-'
+		root := envOr(getenv, "JABBA_HOME", filepath.Join(home, ".jabba"))
+		candidates = importDir(source, filepath.Join(root, "jdk"), func(id string) string {
+			return strings.ReplaceAll(id, "@", "-")
+		})
+	case ImportIntelliJ:
+		// IntelliJ 把下载的 JDK 放在 ~/.jdks/<vendor>-<version>，macOS 上较新的版本使用 ~/Library/Java/JavaVirtualMachines
+		candidates = importDir(source, filepath.Join(home, ".jdks"), nil)
+		if runtime.GOOS == "darwin" {
+			candidates = append(candidates, importDir(source, filepath.Join(home, "Library", "Java", "JavaVirtualMachines"), nil)...)
+		}
+	case ImportJenvClassic:
+		root := envOr(getenv, "JENV_ROOT", filepath.Join(home, ".jenv"))
+		candidates = importJenvClassic(filepath.Join(root, "versions"))
+	default:
+		return nil, fmt.Errorf("%w %q (use %s)", ErrUnknownImportSource, source, strings.Join(ImportSources, ", "))
+	}
+
+	// 不同标识可能指向同一个 JDK，只保留第一个
+	seen := make(map[string]bool)
+	unique := candidates[:0]
+	for _, c := range candidates {
+		if !seen[c.Path] {
+			seen[c.Path] = true
+			unique = append(unique, c)
+		}
+	}
+	return unique, nil
+}
+
+// importDir treats every subdirectory of dir holding a JDK as one installation
+func importDir(source, dir string, name func(id string) string) []ImportCandidate {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var candidates []ImportCandidate
+	for _, entry := range entries {
+		id := entry.Name()
+		// 跳过 sdkman 的 current 链接和隐藏目录
+		if id == "current" || strings.HasPrefix(id, ".") {
+			continue
+		}
+		// sdk install java <id> <path> 等本地安装是指向已有 JDK 的符号链接，注册其目标
+		install, err := filepath.EvalSymlinks(filepath.Join(dir, id))
+		if err != nil {
+			continue
+		}
+		path, ok := importHome(install)
+		if !ok {
+			continue
+		}
+		c := ImportCandidate{ID: id, Name: id, Path: path, Source: source}
+		if name != nil {
+			c.Name = name(id)
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates
+}
+
+// importJenvClassic reads jEnv's versions directory, where every alias of a JDK
+// (17, 17.0, openjdk64-17.0.9, ...) is a symbolic link to the same home. Each home is
+// imported once, under its most specific alias.
+func importJenvClassic(dir string) []ImportCandidate {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	byPath := make(map[string]ImportCandidate)
+	for _, entry := range entries {
+		id := entry.Name()
+		real, err := filepath.EvalSymlinks(filepath.Join(dir, id))
+		if err != nil {
+			continue
+		}
+		path, ok := importHome(real)
+		if !ok {
+			continue
+		}
+		if prev, ok := byPath[path]; ok && !moreSpecific(id, prev.ID) {
+			continue
+		}
+		byPath[path] = ImportCandidate{ID: id, Name: id, Path: path, Source: ImportJenvClassic}
+	}
+
+	candidates := make([]ImportCandidate, 0, len(byPath))
+	for _, c := range byPath {
+		candidates = append(candidates, c)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+	return candidates
+}
+
+// moreSpecific prefers the longer jEnv alias, then the one sorting first
+func moreSpecific(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) > len(b)
+	}
+	return a < b
+}
+
+// importHome returns the Java home of an installation directory, following the
+// Contents/Home layout of macOS bundles
+func importHome(dir string) (string, bool) {
+	if config.ValidateJavaPath(dir) {
+		return dir, true
+	}
+	if home := filepath.Join(dir, "Contents", "Home"); config.ValidateJavaPath(home) {
+		return home, true
+	}
+	return "", false
+}
+
+func envOr(getenv func(string) string, key, fallback string) string {
+	if v := getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,93 @@
+package java
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFindImports(t *testing.T) {
+	// 候选路径是解析过符号链接的真实路径，例如 macOS 上的 /private/var
+	home, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	makeRuntime(t, filepath.Join(home, ".sdkman/candidates/java/17.0.9-tem"), true)
+	makeRuntime(t, filepath.Join(home, ".sdkman/candidates/java/21.0.1-graal"), true)
+	makeRuntime(t, filepath.Join(home, ".asdf/installs/java/temurin-11.0.21+9"), true)
+	makeRuntime(t, filepath.Join(home, ".jabba/jdk/zulu@1.17.0/Contents/Home"), true)
+	makeRuntime(t, filepath.Join(home, ".jdks/corretto-17.0.9"), true)
+	makeRuntime(t, filepath.Join(home, ".jdks/store/temurin-21"), true) // jenv 自己的目录不是 JDK
+	makeRuntime(t, filepath.Join(home, "opt/jdk-17"), true)
+	os.MkdirAll(filepath.Join(home, ".sdkman/candidates/java/broken"), 0755)
+
+	links := map[string]string{
+		".sdkman/candidates/java/current":        filepath.Join(home, ".sdkman/candidates/java/17.0.9-tem"),
+		".jenv/versions/17":                      filepath.Join(home, "opt/jdk-17"),
+		".jenv/versions/17.0":                    filepath.Join(home, "opt/jdk-17"),
+		".jenv/versions/openjdk64-17.0.9":        filepath.Join(home, "opt/jdk-17"),
+		".jenv/versions/corretto64-17.0.9":       filepath.Join(home, ".jdks/corretto-17.0.9"),
+		".jenv/versions/dangling":                filepath.Join(home, "missing"),
+		".asdf/installs/java/temurin-11.0.21+9b": filepath.Join(home, ".asdf/installs/java/temurin-11.0.21+9"),
+	}
+	for link, target := range links {
+		path := filepath.Join(home, link)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.Symlink(target, path); err != nil {
+			t.Skip("无法创建符号链接:", err)
+		}
+	}
+
+	tests := []struct {
+		source string
+		want   map[string]string // 名称 -> 相对 home 的路径
+	}{
+		{ImportSDKMAN, map[string]string{
+			"17.0.9-tem":   ".sdkman/candidates/java/17.0.9-tem",
+			"21.0.1-graal": ".sdkman/candidates/java/21.0.1-graal",
+		}},
+		// 指向同一 JDK 的标识只导入一次
+		{ImportAsdf, map[string]string{"temurin-11.0.21+9": ".asdf/installs/java/temurin-11.0.21+9"}},
+		{ImportJabba, map[string]string{"zulu-1.17.0": ".jabba/jdk/zulu@1.17.0/Contents/Home"}},
+		{ImportIntelliJ, map[string]string{"corretto-17.0.9": ".jdks/corretto-17.0.9"}},
+		// jEnv 的多个别名只保留最具体的一个
+		{ImportJenvClassic, map[string]string{
+			"openjdk64-17.0.9":  "opt/jdk-17",
+			"corretto64-17.0.9": ".jdks/corretto-17.0.9",
+		}},
+	}
+	for _, tt := range tests {
+		candidates, err := findImportsIn(tt.source, home, func(string) string { return "" })
+		if err != nil {
+			t.Fatalf("%s: %v", tt.source, err)
+		}
+		got := make(map[string]string)
+		for _, c := range candidates {
+			if c.Source != tt.source {
+				t.Errorf("%s: 来源应为 %s，实际 %s", tt.source, tt.source, c.Source)
+			}
+			rel, _ := filepath.Rel(home, c.Path)
+			got[c.Name] = filepath.ToSlash(rel)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: 结果为 %v，期望 %v", tt.source, got, tt.want)
+		}
+	}
+
+	// 环境变量可以改变工具目录
+	env := func(key string) string {
+		if key == "SDKMAN_DIR" {
+			return filepath.Join(home, "elsewhere")
+		}
+		return ""
+	}
+	if candidates, _ := findImportsIn(ImportSDKMAN, home, env); len(candidates) != 0 {
+		t.Errorf("SDKMAN_DIR 应覆盖默认目录: %v", candidates)
+	}
+
+	if _, err := findImportsIn("nvm", home, env); !errors.Is(err, ErrUnknownImportSource) {
+		t.Errorf("期望 ErrUnknownImportSource，实际 %v", err)
+	}
+}
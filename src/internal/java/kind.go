@@ -0,0 +1,68 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+// DetectKind works out whether a Java home is a full JDK, a JRE or a custom
+// (jlink) runtime image. It returns "" when the directory holds no runtime at all.
+func DetectKind(home string) string {
+	if config.ValidateJavaPath(home) {
+		return config.KindJDK
+	}
+	if !config.ValidateJavaRuntimePath(home) {
+		return ""
+	}
+
+	rel, _ := ReadRelease(home)
+	switch strings.ToUpper(rel.Values["IMAGE_TYPE"]) {
+	case "JRE":
+		return config.KindJRE
+	case "JDK":
+		// 声明为 JDK 却没有 javac，说明被裁剪过
+		return config.KindCustomImage
+	}
+
+	// Java 8 及更早版本的 JRE
+	if fileExists(filepath.Join(home, "lib", "rt.jar")) {
+		return config.KindJRE
+	}
+	if regularFile(filepath.Join(home, "lib", "modules")) {
+		if strings.Contains(strings.ToLower(directoryHint(home)), "jre") {
+			return config.KindJRE
+		}
+		return config.KindCustomImage
+	}
+	return config.KindJRE
+}
+
+// isRuntimeHome reports whether dir looks like the home of a Java runtime rather than
+// a directory that merely has bin/java (such as /usr with its /usr/bin/java link)
+func isRuntimeHome(dir string) bool {
+	if !config.ValidateJavaRuntimePath(dir) {
+		return false
+	}
+	return fileExists(filepath.Join(dir, "release")) ||
+		regularFile(filepath.Join(dir, "lib", "modules")) ||
+		fileExists(filepath.Join(dir, "lib", "rt.jar"))
+}
+
+// ValidKind reports whether kind names a runtime kind jenv knows about
+func ValidKind(kind string) bool {
+	switch kind {
+	case config.KindJDK, config.KindJRE, config.KindCustomImage:
+		return true
+	}
+	return false
+}
+
+// regularFile reports whether path is a regular file. lib/modules is the runtime
+// image in a Java home but a directory of kernel modules under /usr.
+func regularFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
@@ -0,0 +1,94 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+// makeRuntime creates a fake Java home with bin/java, and bin/javac when withJavac is set
+func makeRuntime(t *testing.T, home string, withJavac bool, files ...string) {
+	t.Helper()
+	exe := ""
+	if runtime.GOOS == "windows" {
+		exe = ".exe"
+	}
+	tools := []string{"java"}
+	if withJavac {
+		tools = append(tools, "javac")
+	}
+	for _, tool := range tools {
+		files = append(files, filepath.Join("bin", tool+exe))
+	}
+	for _, f := range files {
+		path := filepath.Join(home, filepath.FromSlash(f))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("创建目录失败: %v", err)
+		}
+		if err := os.WriteFile(path, nil, 0755); err != nil {
+			t.Fatalf("创建文件失败: %v", err)
+		}
+	}
+}
+
+func TestDetectKind(t *testing.T) {
+	tests := []struct {
+		name     string
+		dir      string
+		javac    bool
+		files    []string
+		release  string
+		expected string
+	}{
+		{name: "完整 JDK", dir: "jdk-17", javac: true, expected: config.KindJDK},
+		{name: "Temurin JRE", dir: "jdk-17-jre", release: "IMAGE_TYPE=\"JRE\"\n", files: []string{"lib/modules"}, expected: config.KindJRE},
+		{name: "Java 8 JRE", dir: "jre1.8.0_392", files: []string{"lib/rt.jar"}, expected: config.KindJRE},
+		{name: "jlink 镜像", dir: "app-runtime", release: "JAVA_VERSION=\"21\"\nMODULES=\"java.base java.logging\"\n", files: []string{"lib/modules"}, expected: config.KindCustomImage},
+		{name: "目录名含 jre", dir: "openjdk-17-jre", files: []string{"lib/modules"}, expected: config.KindJRE},
+		{name: "不是运行时", dir: "empty", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := filepath.Join(t.TempDir(), tt.dir)
+			if tt.expected != "" {
+				makeRuntime(t, home, tt.javac, tt.files...)
+			} else if err := os.MkdirAll(home, 0755); err != nil {
+				t.Fatalf("创建目录失败: %v", err)
+			}
+			if tt.release != "" {
+				writeRelease(t, home, tt.release)
+			}
+			if got := DetectKind(home); got != tt.expected {
+				t.Errorf("期望 %q，实际 %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestScanIncludeJRE(t *testing.T) {
+	root := t.TempDir()
+	makeRuntime(t, filepath.Join(root, "jdk-17"), true)
+	makeRuntime(t, filepath.Join(root, "jre-17"), false, "lib/modules")
+	// 只有 bin/java 的普通目录（类似 /usr）不应被识别
+	makeRuntime(t, filepath.Join(root, "usr"), false)
+
+	if got := ScanJDKWithStats(root).JDKs; len(got) != 1 || got[0].Kind != config.KindJDK {
+		t.Errorf("默认只应找到 JDK，实际 %+v", got)
+	}
+
+	found := ScanJDKWithOptions(root, ScanOptions{IncludeJRE: true}).JDKs
+	if len(found) != 2 {
+		t.Fatalf("期望找到 2 个运行时，实际 %+v", found)
+	}
+	kinds := map[string]string{}
+	for _, jdk := range found {
+		kinds[filepath.Base(jdk.Path)] = jdk.Kind
+	}
+	if kinds["jdk-17"] != config.KindJDK || kinds["jre-17"] != config.KindJRE {
+		t.Errorf("运行时类型错误: %v", kinds)
+	}
+}
@@ -0,0 +1,154 @@
+package java
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+// DefaultNameTemplate names JDKs registered without prompting, e.g. temurin-17
+const DefaultNameTemplate = "{{vendor}}-{{major}}"
+
+// NameFields lists the placeholders a name template may use
+var NameFields = []string{"vendor", "major", "version", "full", "arch", "kind", "implementor", "source", "dir"}
+
+var (
+	namePlaceholder = regexp.MustCompile(`{{\s*([a-zA-Z_]+)\s*}}`)
+	// 名称中不允许出现的字符，与 install 的目录名规则一致，另外排除选择器使用的 '@' 和空白
+	nameUnsafe = regexp.MustCompile(`[/\\:*?"<>|@\s]+`)
+)
+
+// NameTemplate builds registration names from the release metadata of a JDK
+type NameTemplate struct {
+	raw string
+}
+
+// ParseNameTemplate checks that a template only uses known placeholders
+func ParseNameTemplate(raw string) (NameTemplate, error) {
+	if strings.TrimSpace(raw) == "" {
+		return NameTemplate{}, errors.New("empty name template")
+	}
+	for _, m := range namePlaceholder.FindAllStringSubmatch(raw, -1) {
+		if !isNameField(strings.ToLower(m[1])) {
+			return NameTemplate{}, fmt.Errorf("unknown placeholder {{%s}} in name template (use %s)", m[1], strings.Join(NameFields, ", "))
+		}
+	}
+	return NameTemplate{raw: raw}, nil
+}
+
+func isNameField(name string) bool {
+	for _, f := range NameFields {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Render returns the name for a JDK found by a scan. Placeholders without a value
+// are dropped together with the separators around them. When nothing is left, or
+// the name would be read as a selector, the directory name is used instead, and
+// a "jdk-" prefix is added when that is a selector too.
+func (t NameTemplate) Render(jdk JDK) string {
+	values := nameValues(jdk)
+	name := namePlaceholder.ReplaceAllStringFunc(t.raw, func(m string) string {
+		return values[strings.ToLower(namePlaceholder.FindStringSubmatch(m)[1])]
+	})
+	name = nameUnsafe.ReplaceAllString(name, "-")
+	name = collapseSeparators(name)
+	// 能解析为选择器的名称（如只剩主版本号 17）会遮蔽该选择器，同样改用目录名
+	if name == "" || isSelectorName(name) {
+		name = collapseSeparators(nameUnsafe.ReplaceAllString(values["dir"], "-"))
+	}
+	if name == "" || isSelectorName(name) {
+		name = collapseSeparators("jdk-" + name)
+	}
+	return name
+}
+
+// isSelectorName reports whether a name would be read as a selector, such as 17,
+// latest or temurin
+func isSelectorName(name string) bool {
+	_, err := ParseSelector(name)
+	return err == nil
+}
+
+// nameValues reads the values of the placeholders from the JDK's release file
+func nameValues(jdk JDK) map[string]string {
+	desc := describeJDK("", jdk.Path)
+	values := map[string]string{
+		"arch":        desc.Arch,
+		"kind":        jdk.Kind,
+		"implementor": strings.ToLower(desc.Implementor),
+		"source":      jdk.Source,
+		"dir":         filepath.Base(jdk.Path),
+	}
+	if values["kind"] == "" {
+		values["kind"] = desc.Kind
+	}
+	if desc.Vendor != VendorUnknown {
+		values["vendor"] = desc.Vendor
+	}
+	if v, ok := JDKVersion(desc); ok {
+		values["major"] = strconv.Itoa(v.Feature())
+		full := v
+		full.Opt = ""
+		values["full"] = full.String()
+		short := full
+		short.Build = 0
+		values["version"] = short.String()
+	}
+	// macOS 的 Contents/Home 目录名没有意义，改用外层的 .jdk 目录
+	if values["dir"] == "Home" && filepath.Base(filepath.Dir(jdk.Path)) == "Contents" {
+		values["dir"] = strings.TrimSuffix(filepath.Base(filepath.Dir(filepath.Dir(jdk.Path))), ".jdk")
+	}
+	return values
+}
+
+// collapseSeparators removes the separators left around empty placeholders
+func collapseSeparators(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if isNameSeparator(r) && (b.Len() == 0 || i+1 < len(name) && isNameSeparator(rune(name[i+1]))) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return strings.TrimRightFunc(b.String(), isNameSeparator)
+}
+
+func isNameSeparator(r rune) bool {
+	return r == '-' || r == '_' || r == '.'
+}
+
+// UniqueName returns name, or name-2, name-3, ... when taken reports it is in use
+func UniqueName(name string, taken func(string) bool) string {
+	if !taken(name) {
+		return name
+	}
+	for i := 2; ; i++ {
+		if candidate := fmt.Sprintf("%s-%d", name, i); !taken(candidate) {
+			return candidate
+		}
+	}
+}
+
+// AddJDKUnique registers a JDK like AddJDKWithOptions, adding a numeric suffix to
+// the name while it is already registered. It returns the name used.
+func AddJDKUnique(name, path string, opts AddOptions) (string, error) {
+	for i := 1; ; i++ {
+		candidate := name
+		if i > 1 {
+			candidate = fmt.Sprintf("%s-%d", name, i)
+		}
+		err := AddJDKWithOptions(candidate, path, opts)
+		if !errors.Is(err, config.ErrJDKExists) {
+			return candidate, err
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package java
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParseNameTemplate(t *testing.T) {
+	for _, raw := range []string{DefaultNameTemplate, "{{ vendor }}_{{full}}", "jdk-{{MAJOR}}", "fixed"} {
+		if _, err := ParseNameTemplate(raw); err != nil {
+			t.Errorf("模板 %q 应该有效: %v", raw, err)
+		}
+	}
+	for _, raw := range []string{"", "  ", "{{vendor}}-{{bogus}}"} {
+		if _, err := ParseNameTemplate(raw); err == nil {
+			t.Errorf("模板 %q 应该无效", raw)
+		}
+	}
+}
+
+func TestNameTemplateRender(t *testing.T) {
+	dir := t.TempDir()
+	temurin := filepath.Join(dir, "jdk-17.0.9+9")
+	writeRelease(t, temurin, "JAVA_VERSION=\"17.0.9\"\nJAVA_RUNTIME_VERSION=\"17.0.9+9\"\nIMPLEMENTOR=\"Eclipse Adoptium\"\nOS_ARCH=\"x86_64\"\n")
+	bundle := filepath.Join(dir, "zulu-21.jdk", "Contents", "Home")
+	writeRelease(t, bundle, "JAVA_VERSION=\"21.0.1\"\nIMPLEMENTOR=\"Azul Systems, Inc.\"\n")
+	bare := filepath.Join(dir, "my jdk")
+	writeRelease(t, bare, "")
+	unknown := filepath.Join(dir, "openjdk-17")
+	writeRelease(t, unknown, "JAVA_VERSION=\"17.0.2\"\nIMPLEMENTOR=\"Example Corp\"\n")
+	numbered := filepath.Join(dir, "17")
+	writeRelease(t, numbered, "JAVA_VERSION=\"17.0.2\"\n")
+
+	tests := []struct {
+		template string
+		path     string
+		expected string
+	}{
+		{DefaultNameTemplate, temurin, "temurin-17"},
+		{"{{vendor}}-{{full}}", temurin, "temurin-17.0.9+9"},
+		{"{{vendor}}-{{version}}-{{arch}}", temurin, "temurin-17.0.9-amd64"},
+		{"{{implementor}}", temurin, "eclipse-adoptium"},
+		{DefaultNameTemplate, bundle, "zulu-21"},
+		{"{{dir}}", bundle, "zulu-21"},
+		// 没有值的占位符连同分隔符一起去掉
+		{"{{vendor}}-{{major}}-{{source}}", temurin, "temurin-17"},
+		{"{{source}}-{{vendor}}-{{major}}", temurin, "temurin-17"},
+		// 全部为空时使用目录名
+		{DefaultNameTemplate, bare, "my-jdk"},
+		// 不能生成可解析为选择器的名称，如未知厂商时只剩下的 17
+		{DefaultNameTemplate, unknown, "openjdk-17"},
+		{"{{major}}", numbered, "jdk-17"},
+		{"{{vendor}}", temurin, "jdk-17.0.9+9"},
+	}
+	for _, tt := range tests {
+		tmpl, err := ParseNameTemplate(tt.template)
+		if err != nil {
+			t.Fatalf("解析模板 %q 失败: %v", tt.template, err)
+		}
+		if got := tmpl.Render(JDK{Path: tt.path}); got != tt.expected {
+			t.Errorf("Render(%q, %s) = %q, 期望 %q", tt.template, filepath.Base(tt.path), got, tt.expected)
+		}
+	}
+}
+
+func TestUniqueName(t *testing.T) {
+	taken := map[string]bool{"temurin-17": true, "temurin-17-2": true}
+	isTaken := func(name string) bool { return taken[name] }
+	if got := UniqueName("temurin-21", isTaken); got != "temurin-21" {
+		t.Errorf("未占用的名称不应改变, 得到 %q", got)
+	}
+	if got := UniqueName("temurin-17", isTaken); got != "temurin-17-3" {
+		t.Errorf("期望 temurin-17-3, 得到 %q", got)
+	}
+}
@@ -0,0 +1,135 @@
+package java
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/project"
+)
+
+// sdkmanVendors maps the distribution suffixes of SDKMAN! identifiers (17.0.9-tem)
+var sdkmanVendors = map[string]string{
+	"tem":     VendorTemurin,
+	"zulu":    VendorZulu,
+	"amzn":    VendorCorretto,
+	"librca":  VendorLiberica,
+	"nik":     VendorLiberica,
+	"graal":   VendorGraalVM,
+	"graalce": VendorGraalVM,
+	"grl":     VendorGraalVM,
+	"sem":     VendorSemeru,
+	"ms":      VendorMicrosoft,
+	"oracle":  VendorOracle,
+	"open":    VendorOpenJDK,
+	"sapmchn": VendorSAP,
+	"jbr":     VendorJBR,
+}
+
+// leadingVersionPattern takes the numeric part of versions such as 17.0.9+9, 1.8.0_392 or 17.0.9.fx
+var leadingVersionPattern = regexp.MustCompile(`^\d+(?:[._]\d+)*`)
+
+// graalJavaPattern finds the Java version in GraalVM ids that carry their own
+// version: 22.3.r17-grl (SDKMAN!), graalvm-22.3.0+java17 (asdf) and
+// graalvm-ce-java17@22.3.0 (jabba)
+var graalJavaPattern = regexp.MustCompile(`(?i)(?:^|[.\-+_@])(?:r|java)(\d+)(?:$|[.\-+_@])`)
+
+// PinSelector turns the identifier of a version file into a selector expression.
+// .java-version holds jenv names and selectors already; asdf, SDKMAN! and jabba
+// identifiers are mapped onto a distribution and a version prefix.
+func PinSelector(pin project.Pin) (string, error) {
+	var vendor, version string
+	id := pin.Selector
+	switch pin.Format {
+	case "", project.FormatJenv:
+		return id, nil
+	case project.FormatAsdf:
+		// temurin-17.0.9+9, semeru-openj9-17.0.9+9_openj9-0.41.0, 17.0.9
+		vendor, version = splitAtVersion(id)
+	case project.FormatSdkman:
+		// 17.0.9-tem, 21.0.1-graalce
+		version = id
+		if i := strings.LastIndex(id, "-"); i >= 0 {
+			version = id[:i]
+			suffix := strings.ToLower(id[i+1:])
+			if vendor = sdkmanVendors[suffix]; vendor == "" {
+				vendor = NormalizeVendor(suffix)
+			}
+		}
+	case project.FormatJabba:
+		// zulu@1.17.0, adopt@1.11.0-9, openjdk@~1.8
+		version = id
+		if v, ver, ok := strings.Cut(id, "@"); ok {
+			vendor, version = identifierVendor(v), ver
+		}
+		version = strings.TrimLeft(version, "~^=")
+	default:
+		return "", fmt.Errorf("unknown version file format %q", pin.Format)
+	}
+
+	prefix, err := versionPrefix(version, pin.Format == project.FormatJabba)
+	if java := graalJavaPattern.FindStringSubmatch(id); java != nil {
+		// GraalVM 旧的版本号是 GraalVM 自己的版本，Java 版本另外标注
+		prefix, err = java[1], nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot map %q to a JDK: %v", id, err)
+	}
+	if vendor == "" {
+		return prefix, nil
+	}
+	return vendor + "@" + prefix, nil
+}
+
+// splitAtVersion splits an asdf identifier at the first '-' followed by a digit
+func splitAtVersion(id string) (string, string) {
+	if id != "" && id[0] >= '0' && id[0] <= '9' {
+		return "", id
+	}
+	for i := 0; i+1 < len(id); i++ {
+		if id[i] == '-' && id[i+1] >= '0' && id[i+1] <= '9' {
+			return identifierVendor(id[:i]), id[i+1:]
+		}
+	}
+	return identifierVendor(id), ""
+}
+
+// identifierVendor maps names like "temurin-jre", "amazon-corretto" or
+// "graalvm-community" onto a distribution
+func identifierVendor(name string) string {
+	if vendor := NormalizeVendor(name); isKnownVendor(vendor) {
+		return vendor
+	}
+	for _, part := range strings.Split(name, "-") {
+		if vendor := NormalizeVendor(part); isKnownVendor(vendor) {
+			return vendor
+		}
+	}
+	return NormalizeVendor(name)
+}
+
+// versionPrefix reduces a tool's version to the feature.interim.update prefix
+// registered JDKs are matched on. Build numbers are dropped because release files
+// do not always record them, and vendor version schemes such as Zulu's 17.44.53
+// or Corretto's 17.0.9.8.1 only agree with the Java version on the leading part.
+func versionPrefix(version string, jabba bool) (string, error) {
+	numbers := leadingVersionPattern.FindString(version)
+	if numbers == "" {
+		return "", fmt.Errorf("no version in %q", version)
+	}
+	parts := strings.Split(strings.ReplaceAll(numbers, "_", "."), ".")
+	if parts[0] == "1" && len(parts) > 1 {
+		// 1.8.0_392 形式；jabba 还会把 8u192 写成 1.8.192，把 11 写成 1.11.0
+		parts = parts[1:]
+		if jabba && len(parts) > 1 && parts[1] != "0" {
+			parts = append([]string{parts[0], "0"}, parts[1:]...)
+		}
+	} else if len(parts) > 1 && parts[1] != "0" {
+		// 次版本号不为 0 的是厂商版本号，只保留主版本
+		parts = parts[:1]
+	}
+	if len(parts) > 3 {
+		parts = parts[:3]
+	}
+	return strings.Join(parts, "."), nil
+}
@@ -0,0 +1,78 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/whywhathow/jenv/internal/project"
+)
+
+func TestPinSelector(t *testing.T) {
+	tests := []struct {
+		format, id, expected string
+	}{
+		{project.FormatJenv, "temurin@21", "temurin@21"},
+		{project.FormatAsdf, "temurin-17.0.9+9", "temurin@17.0.9"},
+		{project.FormatAsdf, "adoptopenjdk-8.0.392+8", "temurin@8.0.392"},
+		{project.FormatAsdf, "semeru-openj9-17.0.9+9_openj9-0.41.0", "semeru@17.0.9"},
+		{project.FormatAsdf, "corretto-17.0.9.8.1", "corretto@17.0.9"},
+		{project.FormatAsdf, "zulu-17.46.19", "zulu@17"},
+		{project.FormatAsdf, "graalvm-community-21.0.1", "graalvm@21.0.1"},
+		{project.FormatAsdf, "17.0.2", "17.0.2"},
+		{project.FormatSdkman, "17.0.9-tem", "temurin@17.0.9"},
+		{project.FormatSdkman, "21.0.1-amzn", "corretto@21.0.1"},
+		{project.FormatSdkman, "17.0.9.fx-zulu", "zulu@17.0.9"},
+		{project.FormatSdkman, "8.0.392-librca", "liberica@8.0.392"},
+		{project.FormatSdkman, "22.3.r17-grl", "graalvm@17"},
+		{project.FormatSdkman, "21.2.r11-grl", "graalvm@11"},
+		{project.FormatAsdf, "graalvm-22.3.0+java17", "graalvm@17"},
+		{project.FormatJabba, "graalvm-ce-java17@22.3.0", "graalvm@17"},
+		{project.FormatJabba, "zulu@1.17.0", "zulu@17.0"},
+		{project.FormatJabba, "adopt@1.11.0-9", "temurin@11.0"},
+		{project.FormatJabba, "zulu@1.8.192", "zulu@8.0.192"},
+		{project.FormatJabba, "amazon-corretto@~1.8", "corretto@8"},
+		{project.FormatJabba, "1.17", "17"},
+	}
+	for _, tt := range tests {
+		got, err := PinSelector(project.Pin{Selector: tt.id, Format: tt.format})
+		if err != nil || got != tt.expected {
+			t.Errorf("%s %q: 期望 %q，实际 %q (%v)", tt.format, tt.id, tt.expected, got, err)
+		}
+	}
+
+	if _, err := PinSelector(project.Pin{Selector: "system", Format: project.FormatAsdf}); err == nil {
+		t.Error("无版本号的标识符应报错")
+	}
+}
+
+func TestResolveActiveToolFiles(t *testing.T) {
+	dir := t.TempDir()
+	getenv := func(string) string { return "" }
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("写入文件失败: %v", err)
+		}
+		return path
+	}
+
+	path := write(project.SdkmanrcFileName, "# SDKMAN\njava=17.0.2-amzn\n")
+	active, err := resolveActiveIn(dir, getenv, testJDKs(), "jdk11", "config.json")
+	if err != nil || active.JDK.Name != "jdk17b" || active.Source != path+":2" {
+		t.Errorf("期望 .sdkmanrc 选中 jdk17b，实际 %+v (%v)", active, err)
+	}
+
+	path = write(project.ToolVersionsFileName, "nodejs 20.10.0\njava temurin-21.0.1+12 zulu-17.46.19\n")
+	active, err = resolveActiveIn(dir, getenv, testJDKs(), "jdk11", "config.json")
+	if err != nil || active.JDK.Name != "jdk21" || active.Expr != "temurin-21.0.1+12" {
+		t.Errorf("期望 .tool-versions 选中 jdk21，实际 %+v (%v)", active, err)
+	}
+
+	write(project.ToolVersionsFileName, "nodejs 20.10.0\n\njava zulu-8.74.0.17\n")
+	_, err = resolveActiveIn(dir, getenv, testJDKs(), "jdk11", "config.json")
+	if err == nil || !strings.Contains(err.Error(), path+":3") || !strings.Contains(err.Error(), "zulu-8.74.0.17") {
+		t.Errorf("错误应指明文件、行号和标识符，实际 %v", err)
+	}
+}
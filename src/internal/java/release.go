@@ -0,0 +1,145 @@
+package java
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+// Release holds the metadata published in a Java home's `release` file
+type Release struct {
+	JavaVersion        string
+	JavaRuntimeVersion string
+	Implementor        string
+	ImplementorVersion string
+	OSArch             string
+	Modules            []string
+	// Values keeps every key of the file, unquoted
+	Values map[string]string
+}
+
+// ReadRelease parses <home>/release
+func ReadRelease(home string) (Release, error) {
+	f, err := os.Open(filepath.Join(home, "release"))
+	if err != nil {
+		return Release{}, err
+	}
+	defer f.Close()
+
+	values, err := parseReleaseFile(f)
+	if err != nil {
+		return Release{}, err
+	}
+
+	rel := Release{
+		JavaVersion:        values["JAVA_VERSION"],
+		JavaRuntimeVersion: values["JAVA_RUNTIME_VERSION"],
+		Implementor:        values["IMPLEMENTOR"],
+		ImplementorVersion: values["IMPLEMENTOR_VERSION"],
+		OSArch:             values["OS_ARCH"],
+		Values:             values,
+	}
+	if modules := values["MODULES"]; modules != "" {
+		rel.Modules = strings.Fields(modules)
+	}
+	return rel, nil
+}
+
+// parseReleaseFile reads KEY="value" lines, ignoring comments and blank lines
+func parseReleaseFile(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[strings.TrimSpace(key)] = value
+	}
+	return values, scanner.Err()
+}
+
+// describeJDK builds a config entry for the JDK at path, filling in release metadata when available
+func describeJDK(name, path string) config.JDK {
+	jdk := config.JDK{Name: name, Path: path}
+	fillMetadata(&jdk)
+	return jdk
+}
+
+// NameFromRelease suggests a registration name for the Java home at path from its
+// distribution and version, e.g. temurin-17.0.9+9 or corretto-8.0.392+8
+func NameFromRelease(path string) (string, error) {
+	jdk := describeJDK("", path)
+	v, ok := JDKVersion(jdk)
+	if !ok {
+		return "", fmt.Errorf("cannot determine the Java version of %s", path)
+	}
+	v.Opt = ""
+	vendor := jdk.Vendor
+	if vendor == "" || vendor == VendorUnknown {
+		vendor = "jdk"
+	}
+	return vendor + "-" + v.String(), nil
+}
+
+// fillMetadata copies what jenv can learn about jdk.Path into jdk, reporting whether anything changed
+func fillMetadata(jdk *config.JDK) bool {
+	before := *jdk
+	rel, err := ReadRelease(jdk.Path)
+	if err == nil {
+		jdk.JavaVersion = rel.JavaVersion
+		jdk.JavaRuntimeVersion = rel.JavaRuntimeVersion
+		jdk.Implementor = rel.Implementor
+		jdk.ImplementorVersion = rel.ImplementorVersion
+		jdk.OSArch = rel.OSArch
+		jdk.Modules = rel.Modules
+	}
+	jdk.Vendor = classifyVendor(jdk.Path, rel)
+	jdk.Arch = DetectArch(jdk.Path)
+	if kind := DetectKind(jdk.Path); kind != "" {
+		jdk.Kind = kind
+	}
+	jdk.MetadataProbed = true
+	return !reflect.DeepEqual(before, *jdk)
+}
+
+// needsRefresh reports whether an entry predates the metadata jenv records. Entries
+// are probed once; a JDK without a release file or with an unknown arch is not
+// probed again on every command.
+func needsRefresh(jdk config.JDK) bool {
+	return !jdk.MetadataProbed
+}
+
+// RefreshMetadata backfills release metadata for entries registered before jenv recorded it.
+// It only writes the config when at least one entry has not been probed yet.
+func RefreshMetadata() {
+	if cfg == nil {
+		return
+	}
+	var updated []config.JDK
+	for _, jdk := range cfg.JDKList() {
+		if !needsRefresh(jdk) {
+			continue
+		}
+		if fillMetadata(&jdk) {
+			updated = append(updated, jdk)
+		}
+	}
+	if len(updated) > 0 {
+		_ = cfg.UpdateJDK(updated...)
+	}
+}
@@ -0,0 +1,114 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+func writeRelease(t *testing.T, home, content string) {
+	t.Helper()
+	if err := os.MkdirAll(home, 0755); err != nil {
+		t.Fatalf("创建目录失败: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(home, "release"), []byte(content), 0644); err != nil {
+		t.Fatalf("写入 release 文件失败: %v", err)
+	}
+}
+
+func TestReadRelease(t *testing.T) {
+	home := t.TempDir()
+	writeRelease(t, home, `IMPLEMENTOR="Eclipse Adoptium"
+IMPLEMENTOR_VERSION="Temurin-17.0.9+9"
+JAVA_RUNTIME_VERSION="17.0.9+9"
+JAVA_VERSION="17.0.9"
+JAVA_VERSION_DATE="2023-10-17"
+MODULES="java.base java.compiler jdk.compiler"
+OS_ARCH="x86_64"
+# comment
+IMAGE_TYPE=JDK
+`)
+
+	rel, err := ReadRelease(home)
+	if err != nil {
+		t.Fatalf("读取 release 失败: %v", err)
+	}
+	if rel.JavaVersion != "17.0.9" || rel.JavaRuntimeVersion != "17.0.9+9" {
+		t.Errorf("版本解析错误: %q %q", rel.JavaVersion, rel.JavaRuntimeVersion)
+	}
+	if rel.Implementor != "Eclipse Adoptium" || rel.ImplementorVersion != "Temurin-17.0.9+9" {
+		t.Errorf("厂商解析错误: %q %q", rel.Implementor, rel.ImplementorVersion)
+	}
+	if rel.OSArch != "x86_64" {
+		t.Errorf("架构解析错误: %q", rel.OSArch)
+	}
+	if len(rel.Modules) != 3 || rel.Modules[2] != "jdk.compiler" {
+		t.Errorf("模块解析错误: %v", rel.Modules)
+	}
+	if rel.Values["IMAGE_TYPE"] != "JDK" {
+		t.Errorf("未加引号的值解析错误: %q", rel.Values["IMAGE_TYPE"])
+	}
+}
+
+func TestReadReleaseMissing(t *testing.T) {
+	if _, err := ReadRelease(t.TempDir()); err == nil {
+		t.Error("期望缺少 release 文件时返回错误")
+	}
+}
+
+func TestDescribeJDK(t *testing.T) {
+	home := t.TempDir()
+	writeRelease(t, home, "JAVA_VERSION=\"1.8.0_392\"\nIMPLEMENTOR=\"Azul Systems, Inc.\"\n")
+
+	jdk := describeJDK("jdk8", home)
+	if !jdk.HasMetadata() {
+		t.Fatal("期望记录 release 元数据")
+	}
+	if jdk.JavaVersion != "1.8.0_392" || jdk.Implementor != "Azul Systems, Inc." {
+		t.Errorf("元数据错误: %+v", jdk)
+	}
+
+	bare := describeJDK("bare", t.TempDir())
+	if bare.HasMetadata() {
+		t.Errorf("没有 release 文件时不应有元数据: %+v", bare)
+	}
+}
+
+func TestNameFromRelease(t *testing.T) {
+	tests := []struct {
+		release  string
+		expected string
+	}{
+		{"IMPLEMENTOR=\"Eclipse Adoptium\"\nJAVA_RUNTIME_VERSION=\"17.0.9+9-LTS\"\n", "temurin-17.0.9+9"},
+		{"IMPLEMENTOR=\"Amazon.com Inc.\"\nJAVA_VERSION=\"1.8.0_392\"\n", "corretto-8.0.392"},
+	}
+	for _, tt := range tests {
+		home := filepath.Join(t.TempDir(), "x")
+		writeRelease(t, home, tt.release)
+		if name, err := NameFromRelease(home); err != nil || name != tt.expected {
+			t.Errorf("期望 %s，实际 %s (%v)", tt.expected, name, err)
+		}
+	}
+	if _, err := NameFromRelease(filepath.Join(t.TempDir(), "x")); err == nil {
+		t.Error("没有版本信息时应报错")
+	}
+}
+
+func TestRefreshProbesOnce(t *testing.T) {
+	// 没有 release 文件的 JDK 也只检测一次
+	jdk := config.JDK{Name: "bare", Path: t.TempDir()}
+	if !needsRefresh(jdk) {
+		t.Fatal("未检测过的条目需要补充元数据")
+	}
+	if !fillMetadata(&jdk) {
+		t.Fatal("首次检测应更新条目")
+	}
+	if needsRefresh(jdk) {
+		t.Errorf("检测失败后不应再次检测: %+v", jdk)
+	}
+	if fillMetadata(&jdk) {
+		t.Errorf("再次检测不应改变条目: %+v", jdk)
+	}
+}
@@ -0,0 +1,230 @@
+package java
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+// 扫描规则的来源
+const (
+	ScanRuleDefault = "default"
+	ScanRuleConfig  = "config"
+	ScanRuleFlag    = "flag"
+)
+
+// DefaultScanExcludes are the directories general scans skip while scan.exclude is
+// not set in config.json: system folders, build output, IDEs, package managers and
+// anything that looks like a temporary, cache or backup folder
+var DefaultScanExcludes = []string{
+	// System directories
+	"windows", "system32", "syswow64", "drivers", "winsxs",
+	"$recycle.bin", "system volume information", "recovery",
+
+	// Common application directories that won't have JDKs
+	"node_modules", ".git", ".svn", ".hg", "bin", "obj", "debug", "release",
+	"temp", "tmp", "cache", "logs", "log", "backup", "backups",
+	"downloads", "documents", "pictures", "music", "videos", "desktop",
+
+	// Development tools (but not JDK locations)
+	"visual studio", "microsoft visual studio", "jetbrains", "intellij",
+	"eclipse", "netbeans", "android studio", "xamarin",
+
+	// Package managers and build tools
+	"npm", "yarn", "gradle", "maven", ".m2", "nuget", "pip", "conda",
+
+	// Version control and IDE files
+	".vscode", ".idea", ".vs", "target", "build", "dist", "out",
+
+	// Common non-JDK subdirectories
+	"src", "source", "sources", "test", "tests", "doc", "docs", "documentation",
+	"examples", "samples", "demo", "demos", "tutorial", "tutorials",
+
+	// Name patterns
+	"*temp*", "*cache*", "*backup*", "~*",
+}
+
+// ScanRule is one gitignore-style pattern. A pattern without a slash matches the
+// name of a directory, one with a slash matches the end of its path, or the whole
+// path when it starts with '/'. '**' matches any number of directories.
+type ScanRule struct {
+	Pattern string // 去掉 '!' 前缀后的原始模式
+	Include bool   // true 时匹配的目录会被扫描
+	Source  string // default、config 或 flag
+
+	segments []string // 含 '/' 的模式按目录拆分
+	anchored bool     // 含 '/' 时匹配整个路径而不只是目录名
+}
+
+// String returns the rule as it is written in config.json
+func (r ScanRule) String() string {
+	if r.Include {
+		return "!" + r.Pattern
+	}
+	return r.Pattern
+}
+
+// ScanRules decide which directories a scan enters. The last matching rule wins,
+// like in .gitignore; matching ignores case.
+type ScanRules []ScanRule
+
+// LoadScanRules returns scan.exclude from config.json (DefaultScanExcludes when it
+// is empty) and scan.include, followed by the extra rules of one scan. Invalid
+// patterns are reported and left out.
+func LoadScanRules(exclude, include []string) (ScanRules, error) {
+	var settings config.ScanSettings
+	if cfg != nil {
+		settings = cfg.Scan
+	}
+	return newScanRules(settings, exclude, include)
+}
+
+func newScanRules(settings config.ScanSettings, exclude, include []string) (ScanRules, error) {
+	var rules ScanRules
+	var errs []error
+	add := func(source string, include bool, patterns []string) {
+		for _, p := range patterns {
+			if err := rules.add(source, include, p); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if len(settings.Exclude) == 0 {
+		add(ScanRuleDefault, false, DefaultScanExcludes)
+	} else {
+		add(ScanRuleConfig, false, settings.Exclude)
+	}
+	add(ScanRuleConfig, true, settings.Include)
+	add(ScanRuleFlag, false, exclude)
+	add(ScanRuleFlag, true, include)
+
+	if len(errs) > 0 {
+		return rules, errs[0]
+	}
+	return rules, nil
+}
+
+// add parses a pattern; a leading '!' turns an exclude into an include and back
+func (rules *ScanRules) add(source string, include bool, pattern string) error {
+	p := strings.TrimSpace(pattern)
+	if p == "" || strings.HasPrefix(p, "#") {
+		return nil
+	}
+	if strings.HasPrefix(p, "!") {
+		include = !include
+		p = p[1:]
+	}
+	rule := ScanRule{Pattern: p, Include: include, Source: source}
+
+	p = strings.ToLower(strings.TrimSuffix(filepath.ToSlash(p), "/"))
+	if strings.Contains(p, "/") {
+		rule.anchored = true
+		if !strings.HasPrefix(p, "/") {
+			p = "**/" + p
+		}
+		rule.segments = strings.Split(strings.TrimPrefix(p, "/"), "/")
+	} else {
+		rule.segments = []string{p}
+	}
+	for _, seg := range rule.segments {
+		if _, err := path.Match(seg, ""); err != nil {
+			return fmt.Errorf("invalid scan rule %q: %w", pattern, err)
+		}
+	}
+	*rules = append(*rules, rule)
+	return nil
+}
+
+// Match returns the last rule matching dir
+func (rules ScanRules) Match(dir string) (ScanRule, bool) {
+	segments := pathSegments(dir)
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].matches(segments) {
+			return rules[i], true
+		}
+	}
+	return ScanRule{}, false
+}
+
+// Allows reports whether a scan enters dir
+func (rules ScanRules) Allows(dir string) bool {
+	rule, ok := rules.Match(dir)
+	return !ok || rule.Include
+}
+
+// ScanMatch is a directory together with the rule deciding whether scans enter it
+type ScanMatch struct {
+	Dir  string
+	Rule ScanRule
+}
+
+// Explain returns the rules matching dir and each directory above it, starting at
+// dir. An excluded parent only hides dir from scans starting at or above it.
+func (rules ScanRules) Explain(dir string) []ScanMatch {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = filepath.Clean(dir)
+	}
+	var matches []ScanMatch
+	for p := abs; ; p = filepath.Dir(p) {
+		if rule, ok := rules.Match(p); ok {
+			matches = append(matches, ScanMatch{Dir: p, Rule: rule})
+		}
+		if filepath.Dir(p) == p {
+			return matches
+		}
+	}
+}
+
+func (r ScanRule) matches(segments []string) bool {
+	if !r.anchored {
+		if len(segments) == 0 {
+			return false
+		}
+		ok, _ := path.Match(r.segments[0], segments[len(segments)-1])
+		return ok
+	}
+	return matchSegments(r.segments, segments)
+}
+
+// matchSegments matches a path directory by directory, '**' standing for any number
+// of them
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		// 末尾的 '**' 只匹配目录之下的内容，不包括目录本身
+		if len(pattern) == 1 {
+			return len(segments) > 0
+		}
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	ok, _ := path.Match(pattern[0], segments[0])
+	return ok && matchSegments(pattern[1:], segments[1:])
+}
+
+// pathSegments splits the lower-cased absolute path of dir into its directories
+func pathSegments(dir string) []string {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	var segments []string
+	for _, seg := range strings.Split(strings.ToLower(filepath.ToSlash(dir)), "/") {
+		if seg != "" {
+			segments = append(segments, seg)
+		}
+	}
+	return segments
+}
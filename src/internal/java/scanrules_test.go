@@ -0,0 +1,101 @@
+package java
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+func TestDefaultScanRules(t *testing.T) {
+	rules, err := newScanRules(config.ScanSettings{}, nil, nil)
+	if err != nil {
+		t.Fatalf("内置规则无效: %v", err)
+	}
+	// 与原先 shouldScanDirectory 的行为一致
+	tests := map[string]bool{
+		"/opt/jdk-17":                  true,
+		"/opt/build-tools":             true,
+		"/usr/lib/jvm":                 true,
+		"/home/me/project/build":       false,
+		"/home/me/project/Target":      false,
+		"C:/$Recycle.Bin":              false,
+		"/var/MyTemplates":             false,
+		"/home/me/.cache":              false,
+		"/srv/old-backups":             false,
+		"/home/me/~jdk":                false,
+		"/home/me/node_modules":        false,
+		"/home/me/Visual Studio":       false,
+		"/usr/lib/jvm/java-17-openjdk": true,
+	}
+	for dir, want := range tests {
+		if got := rules.Allows(filepath.FromSlash(dir)); got != want {
+			t.Errorf("Allows(%s) = %v, 期望 %v", dir, got, want)
+		}
+	}
+}
+
+func TestScanRulesPatterns(t *testing.T) {
+	settings := config.ScanSettings{
+		Exclude: []string{"build", "/opt/vendor", "apps/*/jbr", "**/legacy/**", "# 注释", "!keep-*"},
+		Include: []string{"build"},
+	}
+	rules, err := newScanRules(settings, []string{"keep-out"}, nil)
+	if err != nil {
+		t.Fatalf("解析规则失败: %v", err)
+	}
+	tests := map[string]bool{
+		"/a/build":           true,  // include 在 exclude 之后，后匹配的生效
+		"/opt/vendor":        false, // 以 '/' 开头时匹配整个路径
+		"/srv/opt/vendor":    true,
+		"/x/apps/idea/jbr":   false, // 含 '/' 时匹配路径结尾
+		"/x/apps/idea/a/jbr": true,
+		"/x/legacy/jdk8":     false,
+		"/x/legacy":          true,
+		"/x/keep-jdk":        true,
+		"/x/keep-out":        false, // 命令行规则排在配置之后
+		"/x/README":          true,
+		"/x/Apps/IDEA/JBR":   false, // 忽略大小写
+	}
+	for dir, want := range tests {
+		if got := rules.Allows(filepath.FromSlash(dir)); got != want {
+			t.Errorf("Allows(%s) = %v, 期望 %v", dir, got, want)
+		}
+	}
+
+	if _, err := newScanRules(config.ScanSettings{}, []string{"jdk["}, nil); err == nil {
+		t.Error("无效的模式应返回错误")
+	}
+}
+
+func TestScanRulesExplain(t *testing.T) {
+	rules, err := newScanRules(config.ScanSettings{}, nil, []string{"jdk-*"})
+	if err != nil {
+		t.Fatalf("解析规则失败: %v", err)
+	}
+	dir := filepath.FromSlash("/srv/build/cache/jdk-17")
+	matches := rules.Explain(dir)
+	want := []struct {
+		dir     string
+		pattern string
+		include bool
+	}{
+		{"/srv/build/cache/jdk-17", "jdk-*", true},
+		{"/srv/build/cache", "*cache*", false},
+		{"/srv/build", "build", false},
+	}
+	if len(matches) != len(want) {
+		t.Fatalf("期望 %d 条匹配, 得到 %+v", len(want), matches)
+	}
+	for i, w := range want {
+		m := matches[i]
+		abs, _ := filepath.Abs(filepath.FromSlash(w.dir))
+		if m.Dir != abs || m.Rule.Pattern != w.pattern || m.Rule.Include != w.include {
+			t.Errorf("第 %d 条匹配 = %s %q include=%v, 期望 %s %q include=%v",
+				i, m.Dir, m.Rule.Pattern, m.Rule.Include, abs, w.pattern, w.include)
+		}
+	}
+	if m := matches[0]; m.Rule.Source != ScanRuleFlag || matches[1].Rule.Source != ScanRuleDefault {
+		t.Errorf("规则来源错误: %+v", matches)
+	}
+}
@@ -18,9 +18,13 @@ import (
 type JDK struct {
 	Name string
 	Path string
+	Kind string // jdk、jre 或 custom-image
+	// Source 为提供该运行时的工具，如 gradle、jetbrains，普通扫描结果为空
+	Source string
 }
 
 var ErrNoJDKConfigured = errors.New("no JDK configured")
+var ErrNoCompiler = errors.New("Java runtime has no compiler (javac)")
 var cfg *config.Config
 
 // Simple cache for directory scan results to improve performance and avoid redundant scans
@@ -85,16 +89,48 @@ func ListJdks() (map[string]config.JDK, error) {
 	return cfg.Jdks, nil
 }
 
+// AddOptions 控制 JDK 注册时的校验
+type AddOptions struct {
+	// CrossTarget 允许注册非本机架构的 JDK，例如仅用于 jlink 交叉编译
+	CrossTarget bool
+	// Kind 为期望的运行时类型；jre 或 custom-image 允许注册没有 javac 的运行时
+	Kind string
+	// Source 记录提供该 JDK 的工具
+	Source string
+}
+
 // AddJDK 添加新的 JDK
 func AddJDK(name, path string) error {
+	return AddJDKWithOptions(name, path, AddOptions{})
+}
+
+// AddJDKWithOptions 添加新的 JDK，并按 opts 校验
+func AddJDKWithOptions(name, path string, opts AddOptions) error {
 	// 获取配置实例
 	//cfg, err := config.GetInstance()
 	//if err != nil {
 	//	return err
 	//}
 
+	// 记录 release 文件中的版本信息
+	jdk := describeJDK(name, path)
+	jdk.Source = opts.Source
+
+	// 没有 javac 的运行时需要显式指定类型
+	if !jdk.HasCompiler() && (opts.Kind == "" || opts.Kind == config.KindJDK) {
+		return fmt.Errorf("%w: %s is a %s", ErrNoCompiler, path, jdk.Kind)
+	}
+
+	// 非本机架构的 JDK 需要显式标记
+	if !IsNativeArch(jdk.Arch) && !archEmulated(jdk.Arch) {
+		if !opts.CrossTarget {
+			return fmt.Errorf("%w: %s is built for %s, this machine is %s", ErrArchMismatch, path, jdk.Arch, runtime.GOARCH)
+		}
+		jdk.CrossTarget = true
+	}
+
 	// 添加 JDK
-	if err := cfg.AddJDK(name, path); err != nil {
+	if err := cfg.RegisterJDK(jdk); err != nil {
 		return err
 	}
 
@@ -119,7 +155,7 @@ func RemoveJDK(name string) error {
 	return cfg.Save()
 }
 
-// UseJDK 设置当前使用的 JDK
+// UseJDK 设置当前使用的 JDK，name 可以是 JDK 名称或选择器表达式（如 17、temurin@21）
 func UseJDK(name string) error {
 	// 获取配置实例
 	//cfg, err := config.GetInstance()
@@ -127,11 +163,17 @@ func UseJDK(name string) error {
 	//	return err
 	//}
 
-	// 检查 JDK 是否存在
-	jdk, exists := cfg.Jdks[name]
+	// 解析 JDK 名称或选择器
+	res, err := ResolveJDK(name)
+	if err != nil {
+		return err
+	}
+	jdk := res.JDK
+	name = jdk.Name
 
-	if !exists {
-		return config.ErrJDKNotFound
+	// 无法在本机运行的 JDK 不能设为当前 JDK
+	if _, err := CheckArch(jdk); err != nil {
+		return err
 	}
 
 	// 创建符号链接
@@ -199,6 +241,14 @@ const maxDepth = 5
 // numWorkers 定义了并发的工人数量
 var numWorkers = runtime.NumCPU() * 2 // 保持动态，但可以根据需要调整
 
+// ScanOptions 控制扫描时识别哪些运行时
+type ScanOptions struct {
+	// IncludeJRE 同时识别没有 javac 的 JRE 和 jlink 镜像
+	IncludeJRE bool
+	// Rules 决定进入哪些目录，为空时读取 config.json 中的 scan 规则
+	Rules ScanRules
+}
+
 // ScanJDK 是一个简单的包装器，只返回找到的JDK列表
 func ScanJDK(dir string) []JDK {
 	result := ScanJDKWithStats(dir)
@@ -207,13 +257,30 @@ func ScanJDK(dir string) []JDK {
 
 // ScanJDKWithStats 使用健壮的并发模型执行JDK扫描并返回详细统计信息
 func ScanJDKWithStats(dir string) ScanResult {
-	start := time.Now()
+	return ScanJDKWithOptions(dir, ScanOptions{})
+}
+
+// ScanJDKWithOptions 与 ScanJDKWithStats 相同，但可以通过 opts 调整识别规则
+func ScanJDKWithOptions(dir string, opts ScanOptions) ScanResult {
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		fmt.Printf("Directory does not exist: %s\n", dir)
-		return ScanResult{Duration: time.Since(start)}
+		return ScanResult{}
 	}
+	stats := scanDir(dir, opts)
+	if len(stats.JDKs) == 0 {
+		fmt.Println("No valid JDKs found in the specified directory")
+	}
+	return stats
+}
+
+// scanDir 执行扫描但不输出提示，供同时扫描多个目录时使用
+func scanDir(dir string, opts ScanOptions) ScanResult {
+	start := time.Now()
 
 	existingPaths := getExistingJDKPaths()
+	if opts.Rules == nil {
+		opts.Rules, _ = LoadScanRules(nil, nil)
+	}
 
 	// --- 调度中心-工人 并发模型 ---
 	tasksChan := make(chan WorkerTask, numWorkers*2)
@@ -223,7 +290,7 @@ func ScanJDKWithStats(dir string) ScanResult {
 	// 1. 启动固定数量的工人
 	for i := 0; i < numWorkers; i++ {
 		workerWg.Add(1)
-		go jdkScannerWorker(tasksChan, resultsChan, &workerWg, existingPaths)
+		go jdkScannerWorker(tasksChan, resultsChan, &workerWg, existingPaths, opts)
 	}
 
 	// 2. 启动调度中心 goroutine
@@ -285,22 +352,17 @@ func ScanJDKWithStats(dir string) ScanResult {
 
 	stats.JDKs = finalJDKs
 	stats.Duration = time.Since(start)
-
-	if len(stats.JDKs) == 0 {
-		fmt.Println("No valid JDKs found in the specified directory")
-	}
-
 	return stats
 }
 
 // jdkScannerWorker 是并发模型中的“工人”，负责处理单个目录的扫描
-func jdkScannerWorker(tasks <-chan WorkerTask, results chan<- WorkerResult, wg *sync.WaitGroup, existingPaths map[string]bool) {
+func jdkScannerWorker(tasks <-chan WorkerTask, results chan<- WorkerResult, wg *sync.WaitGroup, existingPaths map[string]bool, opts ScanOptions) {
 	defer wg.Done()
 	for task := range tasks {
 		res := WorkerResult{}
 
 		// 预过滤：在读取目录之前进行检查
-		if !shouldScanDirectory(task.Path) || task.Depth > maxDepth {
+		if !opts.Rules.Allows(task.Path) || task.Depth > maxDepth {
 			// if task.Depth > maxDepth {
 			res.IsSkipped = true
 			results <- res
@@ -315,10 +377,15 @@ func jdkScannerWorker(tasks <-chan WorkerTask, results chan<- WorkerResult, wg *
 
 		// 核心逻辑：检查当前目录是否为JDK
 		if config.ValidateJavaPath(task.Path) {
-			res.FoundJDK = &JDK{Path: task.Path, Name: filepath.Base(task.Path)}
+			res.FoundJDK = &JDK{Path: task.Path, Name: filepath.Base(task.Path), Kind: config.KindJDK}
 			results <- res
 			continue // 找到JDK后，不再扫描其子目录
 		}
+		if opts.IncludeJRE && isRuntimeHome(task.Path) {
+			res.FoundJDK = &JDK{Path: task.Path, Name: filepath.Base(task.Path), Kind: DetectKind(task.Path)}
+			results <- res
+			continue
+		}
 
 		// 读取子目录
 		entries, err := os.ReadDir(task.Path)
@@ -374,51 +441,3 @@ func isPathExcluded(path string, existingPaths map[string]bool) bool {
 	}
 	return false
 }
-
-// shouldScanDirectory performs aggressive pre-filtering to skip directories that are unlikely to contain JDKs
-func shouldScanDirectory(path string) bool {
-
-	// Get directory name for filtering
-	dirName := strings.ToLower(filepath.Base(path))
-
-	// Skip common non-JDK directories aggressively
-	skipDirs := []string{
-		// System directories
-		"windows", "system32", "syswow64", "drivers", "winsxs",
-		"$recycle.bin", "system volume information", "recovery",
-
-		// Common application directories that won't have JDKs
-		"node_modules", ".git", ".svn", ".hg", "bin", "obj", "debug", "release",
-		"temp", "tmp", "cache", "logs", "log", "backup", "backups",
-		"downloads", "documents", "pictures", "music", "videos", "desktop",
-
-		// Development tools (but not JDK locations)
-		"visual studio", "microsoft visual studio", "jetbrains", "intellij",
-		"eclipse", "netbeans", "android studio", "xamarin",
-
-		// Package managers and build tools
-		"npm", "yarn", "gradle", "maven", ".m2", "nuget", "pip", "conda",
-
-		// Version control and IDE files
-		".vscode", ".idea", ".vs", "target", "build", "dist", "out",
-
-		// Common non-JDK subdirectories
-		"src", "source", "sources", "test", "tests", "doc", "docs", "documentation",
-		"examples", "samples", "demo", "demos", "tutorial", "tutorials",
-	}
-
-	for _, skip := range skipDirs {
-		if dirName == skip {
-			return false
-		}
-	}
-
-	// Skip directories with certain patterns
-	if strings.Contains(dirName, "temp") ||
-		strings.Contains(dirName, "cache") ||
-		strings.Contains(dirName, "backup") ||
-		strings.HasPrefix(dirName, "~") {
-		return false
-	}
-	return true
-}
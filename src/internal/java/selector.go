@@ -0,0 +1,315 @@
+package java
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+// ErrInvalidSelector is returned when an expression is neither a JDK name nor a valid selector
+var ErrInvalidSelector = errors.New("invalid JDK selector")
+
+// Selector describes which registered JDKs an expression such as
+// "17", ">=11 <17", "temurin@21" or "latest-lts" accepts
+type Selector struct {
+	Raw         string
+	Vendor      string
+	Constraints []Constraint
+	LTS         bool // only long-term-support feature releases
+}
+
+// Constraint is a single version comparison, e.g. ">=11" or "17.0" (prefix match)
+type Constraint struct {
+	Op      string // "", "=", "!=", ">", ">=", "<", "<="
+	Version Version
+	// precision is the number of version components the user wrote, so "17" matches 17.0.9
+	precision int
+}
+
+// Resolution is the outcome of resolving an expression against the registered JDKs
+type Resolution struct {
+	JDK        config.JDK
+	Selector   Selector
+	Candidates []config.JDK // every matching JDK, best first
+	Reason     string
+}
+
+var constraintOps = []string{">=", "<=", "!=", ">", "<", "="}
+
+// ParseSelector parses a selector expression.
+//
+// Supported forms:
+//
+//	17, 17.0.9, 1.8        version prefix
+//	>=11 <17               space separated constraints, all must match
+//	temurin@21             distribution plus any of the above
+//	latest, lts, latest-lts
+//	temurin                a known distribution, newest version wins
+func ParseSelector(expr string) (Selector, error) {
+	sel := Selector{Raw: strings.TrimSpace(expr)}
+	rest := sel.Raw
+	if rest == "" {
+		return Selector{}, fmt.Errorf("%w: empty expression", ErrInvalidSelector)
+	}
+
+	if vendor, version, ok := strings.Cut(rest, "@"); ok {
+		sel.Vendor = NormalizeVendor(vendor)
+		if sel.Vendor == "" {
+			return Selector{}, fmt.Errorf("%w: missing distribution in %q", ErrInvalidSelector, expr)
+		}
+		rest = strings.TrimSpace(version)
+		if rest == "" {
+			rest = "latest"
+		}
+	}
+
+	switch strings.ToLower(rest) {
+	case "latest":
+		return sel, nil
+	case "lts", "latest-lts":
+		sel.LTS = true
+		return sel, nil
+	}
+
+	// 单独的厂商名称，如 "temurin"
+	if sel.Vendor == "" && isKnownVendor(NormalizeVendor(rest)) {
+		sel.Vendor = NormalizeVendor(rest)
+		return sel, nil
+	}
+
+	for _, term := range strings.Fields(normalizeConstraintSpacing(rest)) {
+		c, err := parseConstraint(term)
+		if err != nil {
+			return Selector{}, fmt.Errorf("%w: %q: %v", ErrInvalidSelector, expr, err)
+		}
+		sel.Constraints = append(sel.Constraints, c)
+	}
+	return sel, nil
+}
+
+// normalizeConstraintSpacing turns ">= 11 < 17" into ">=11 <17"
+func normalizeConstraintSpacing(s string) string {
+	fields := strings.Fields(s)
+	var out []string
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if isConstraintOp(f) && i+1 < len(fields) {
+			f += fields[i+1]
+			i++
+		}
+		out = append(out, f)
+	}
+	return strings.Join(out, " ")
+}
+
+func isConstraintOp(s string) bool {
+	for _, op := range constraintOps {
+		if s == op {
+			return true
+		}
+	}
+	return false
+}
+
+func parseConstraint(term string) (Constraint, error) {
+	c := Constraint{}
+	for _, op := range constraintOps {
+		if strings.HasPrefix(term, op) {
+			c.Op = op
+			term = term[len(op):]
+			break
+		}
+	}
+	v, err := ParseVersion(term)
+	if err != nil {
+		return Constraint{}, err
+	}
+	c.Version = v
+	c.precision = versionPrecision(term)
+	return c, nil
+}
+
+// versionPrecision counts the components written by the user, treating 1.8 as a single component
+func versionPrecision(term string) int {
+	core := term
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	core = strings.ReplaceAll(core, "_", ".")
+	n := len(strings.Split(core, "."))
+	if strings.HasPrefix(core, "1.") && n > 1 {
+		n--
+	}
+	return n
+}
+
+// Matches reports whether v satisfies the constraint
+func (c Constraint) Matches(v Version) bool {
+	prefix := c.prefixMatches(v)
+	cmp := v.Compare(c.Version)
+	switch c.Op {
+	case "", "=":
+		return prefix
+	case "!=":
+		return !prefix
+	case ">":
+		return cmp > 0 && !prefix
+	case ">=":
+		return cmp >= 0 || prefix
+	case "<":
+		return cmp < 0 && !prefix
+	case "<=":
+		return cmp <= 0 || prefix
+	}
+	return false
+}
+
+// prefixMatches compares only the components the user wrote, so "17.0" matches 17.0.9+9
+func (c Constraint) prefixMatches(v Version) bool {
+	for i := 0; i < c.precision; i++ {
+		if v.number(i) != c.Version.number(i) {
+			return false
+		}
+	}
+	if c.Version.Pre != "" && v.Pre != c.Version.Pre {
+		return false
+	}
+	if c.Version.Build > 0 && v.Build != c.Version.Build {
+		return false
+	}
+	return true
+}
+
+// Matches reports whether a registered JDK satisfies the selector
+func (s Selector) Matches(jdk config.JDK) bool {
+	if s.Vendor != "" && jdk.Vendor != s.Vendor {
+		return false
+	}
+	v, ok := JDKVersion(jdk)
+	if !ok {
+		// 无法确定版本的JDK只能通过厂商选择器匹配
+		return len(s.Constraints) == 0 && !s.LTS && s.Vendor != ""
+	}
+	if s.LTS && !v.IsLTS() {
+		return false
+	}
+	for _, c := range s.Constraints {
+		if !c.Matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// ResolveJDK picks the registered JDK an expression refers to.
+// An exact name always wins; otherwise the expression is parsed as a selector.
+func ResolveJDK(expr string) (Resolution, error) {
+	return resolveIn(expr, cfg.Jdks)
+}
+
+// resolveIn resolves expr against jdks. When several JDKs match, the choice is
+// deterministic: JDKs native to this machine beat other architectures, JDKs with a
+// known version beat the others, GA builds beat early access builds, then the highest
+// version wins, then the name sorts first.
+func resolveIn(expr string, jdks map[string]config.JDK) (Resolution, error) {
+	if jdk, ok := jdks[expr]; ok {
+		return Resolution{
+			JDK:        jdk,
+			Candidates: []config.JDK{jdk},
+			Reason:     fmt.Sprintf("%q is a registered JDK name", expr),
+		}, nil
+	}
+
+	sel, err := ParseSelector(expr)
+	if err != nil {
+		return Resolution{}, fmt.Errorf("%w: %q is not a registered JDK name (%v)", config.ErrJDKNotFound, expr, err)
+	}
+
+	var candidates []config.JDK
+	for _, jdk := range jdks {
+		if sel.Matches(jdk) {
+			candidates = append(candidates, jdk)
+		}
+	}
+	if len(candidates) == 0 {
+		return Resolution{Selector: sel}, fmt.Errorf("%w: no registered JDK matches %q", config.ErrJDKNotFound, expr)
+	}
+	rankCandidates(candidates)
+
+	best := candidates[0]
+	return Resolution{
+		JDK:        best,
+		Selector:   sel,
+		Candidates: candidates,
+		Reason:     explainChoice(sel, best, candidates),
+	}, nil
+}
+
+// rankCandidates sorts matching JDKs best first
+func rankCandidates(jdks []config.JDK) {
+	sort.SliceStable(jdks, func(i, j int) bool {
+		c, _ := compareCandidates(jdks[i], jdks[j])
+		return c < 0
+	})
+}
+
+// compareCandidates returns a negative number when a ranks before b, together with
+// the rule that decided between them
+func compareCandidates(a, b config.JDK) (int, string) {
+	// 本机架构优先
+	if na, nb := IsNativeArch(a.Arch), IsNativeArch(b.Arch); na != nb {
+		return preferFirst(na), "the one built for this machine"
+	}
+	va, okA := JDKVersion(a)
+	vb, okB := JDKVersion(b)
+	if okA != okB {
+		return preferFirst(okA), "the one with a known version"
+	}
+	if okA {
+		if va.IsEA() != vb.IsEA() {
+			return preferFirst(!va.IsEA()), "the GA build"
+		}
+		if c := va.Compare(vb); c != 0 {
+			return -c, "the highest version"
+		}
+	}
+	return strings.Compare(a.Name, b.Name), "the first by name"
+}
+
+func preferFirst(first bool) int {
+	if first {
+		return -1
+	}
+	return 1
+}
+
+// explainChoice describes the pick, naming the rule that decided between the two
+// best candidates
+func explainChoice(sel Selector, best config.JDK, candidates []config.JDK) string {
+	version := "unknown version"
+	if v, ok := JDKVersion(best); ok {
+		version = v.Raw
+	}
+	if len(candidates) == 1 {
+		return fmt.Sprintf("%q matched only %s (%s)", sel.Raw, best.Name, version)
+	}
+	names := make([]string, len(candidates))
+	for i, jdk := range candidates {
+		names[i] = jdk.Name
+	}
+	_, rule := compareCandidates(candidates[0], candidates[1])
+	return fmt.Sprintf("%q matched %d JDKs (%s); picked %s, %s (%s)",
+		sel.Raw, len(candidates), strings.Join(names, ", "), best.Name, rule, version)
+}
+
+func isKnownVendor(name string) bool {
+	for _, a := range vendorAliases {
+		if a.vendor == name {
+			return true
+		}
+	}
+	return false
+}
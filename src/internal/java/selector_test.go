@@ -0,0 +1,139 @@
+package java
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+func testJDKs() map[string]config.JDK {
+	return map[string]config.JDK{
+		"jdk8":      {Name: "jdk8", Path: "/opt/jdk8", JavaRuntimeVersion: "1.8.0_392-b08", Vendor: VendorTemurin},
+		"jdk11":     {Name: "jdk11", Path: "/opt/jdk11", JavaRuntimeVersion: "11.0.21+9", Vendor: VendorZulu},
+		"jdk17":     {Name: "jdk17", Path: "/opt/jdk17", JavaRuntimeVersion: "17.0.9+9", Vendor: VendorTemurin},
+		"jdk17b":    {Name: "jdk17b", Path: "/opt/jdk17b", JavaRuntimeVersion: "17.0.2+8", Vendor: VendorCorretto},
+		"jdk21":     {Name: "jdk21", Path: "/opt/jdk21", JavaRuntimeVersion: "21.0.1+12-LTS", Vendor: VendorTemurin},
+		"jdk21-zul": {Name: "jdk21-zul", Path: "/opt/jdk21-zul", JavaRuntimeVersion: "21.0.1+12-LTS", Vendor: VendorZulu},
+		"jdk23-ea":  {Name: "jdk23-ea", Path: "/opt/jdk23-ea", JavaRuntimeVersion: "23-ea+5", Vendor: VendorOpenJDK},
+		"jdk22":     {Name: "jdk22", Path: "/opt/jdk22", JavaRuntimeVersion: "22.0.1+8", Vendor: VendorOracle},
+	}
+}
+
+func TestResolveJDK(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+		count    int
+	}{
+		{expr: "jdk17b", expected: "jdk17b", count: 1},
+		{expr: "17", expected: "jdk17", count: 2},
+		{expr: "17.0.2", expected: "jdk17b", count: 1},
+		{expr: "1.8", expected: "jdk8", count: 1},
+		{expr: "8", expected: "jdk8", count: 1},
+		{expr: ">=11 <17", expected: "jdk11", count: 1},
+		{expr: ">= 11 < 17", expected: "jdk11", count: 1},
+		{expr: "<=17", expected: "jdk17", count: 4},
+		{expr: ">17", expected: "jdk22", count: 4},
+		{expr: "temurin@21", expected: "jdk21", count: 1},
+		{expr: "adoptium@>=17", expected: "jdk21", count: 2},
+		{expr: "zulu", expected: "jdk21-zul", count: 2},
+		{expr: "latest", expected: "jdk22", count: 8},
+		{expr: "latest-lts", expected: "jdk21", count: 6},
+		{expr: "corretto@latest", expected: "jdk17b", count: 1},
+		{expr: "23", expected: "jdk23-ea", count: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			res, err := resolveIn(tt.expr, testJDKs())
+			if err != nil {
+				t.Fatalf("解析失败: %v", err)
+			}
+			if res.JDK.Name != tt.expected {
+				t.Errorf("期望 %s，实际 %s (%s)", tt.expected, res.JDK.Name, res.Reason)
+			}
+			if len(res.Candidates) != tt.count {
+				t.Errorf("期望 %d 个候选，实际 %d", tt.count, len(res.Candidates))
+			}
+			if res.Reason == "" {
+				t.Error("缺少选择原因")
+			}
+		})
+	}
+}
+
+func TestResolveJDKDeterministic(t *testing.T) {
+	// 版本相同的两个 JDK 按名称排序
+	for i := 0; i < 20; i++ {
+		res, err := resolveIn("21", testJDKs())
+		if err != nil {
+			t.Fatalf("解析失败: %v", err)
+		}
+		if res.JDK.Name != "jdk21" {
+			t.Fatalf("结果不稳定: %s", res.JDK.Name)
+		}
+	}
+}
+
+func TestResolveJDKReason(t *testing.T) {
+	foreign := "arm64"
+	if runtime.GOARCH == foreign {
+		foreign = "amd64"
+	}
+	jdks := testJDKs()
+	jdks["jdk25-foreign"] = config.JDK{Name: "jdk25-foreign", Path: "/opt/jdk25", JavaRuntimeVersion: "25.0.1+8", Vendor: VendorTemurin, Arch: foreign}
+
+	// 选择原因应说明真正决定结果的规则
+	tests := []struct {
+		expr     string
+		expected string
+		rule     string
+	}{
+		{expr: "17", expected: "jdk17", rule: "the highest version"},
+		{expr: "21", expected: "jdk21", rule: "the first by name"},
+		{expr: ">=22 <25", expected: "jdk22", rule: "the GA build"},
+		{expr: "temurin@>=21", expected: "jdk21", rule: "the one built for this machine"},
+	}
+	for _, tt := range tests {
+		res, err := resolveIn(tt.expr, jdks)
+		if err != nil {
+			t.Fatalf("解析 %q 失败: %v", tt.expr, err)
+		}
+		if res.JDK.Name != tt.expected || !strings.Contains(res.Reason, tt.rule) {
+			t.Errorf("%q: 期望 %s（%s），实际 %s: %s", tt.expr, tt.expected, tt.rule, res.JDK.Name, res.Reason)
+		}
+	}
+}
+
+func TestResolveJDKErrors(t *testing.T) {
+	for _, expr := range []string{"16", "jdk99", "temurin@11", "@17", ""} {
+		_, err := resolveIn(expr, testJDKs())
+		if err == nil {
+			t.Errorf("期望 %q 解析失败", expr)
+			continue
+		}
+		if !errors.Is(err, config.ErrJDKNotFound) {
+			t.Errorf("期望 ErrJDKNotFound，实际 %v", err)
+		}
+	}
+}
+
+func TestParseSelector(t *testing.T) {
+	sel, err := ParseSelector("temurin@>=11 <17")
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if sel.Vendor != VendorTemurin || len(sel.Constraints) != 2 {
+		t.Errorf("解析结果错误: %+v", sel)
+	}
+	if sel.Constraints[0].Op != ">=" || sel.Constraints[1].Op != "<" {
+		t.Errorf("运算符解析错误: %+v", sel.Constraints)
+	}
+
+	if _, err := ParseSelector(">=abc"); !errors.Is(err, ErrInvalidSelector) {
+		t.Errorf("期望 ErrInvalidSelector，实际 %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package java
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/config"
+	"github.com/whywhathow/jenv/internal/constants"
+)
+
+// ErrToolNotFound is returned when the JDK in effect does not ship the requested tool
+var ErrToolNotFound = errors.New("tool not found in JDK")
+
+// shimMarker 标记由 jenv 生成的 shim，rehash 只会删除带此标记的文件
+const shimMarker = "jenv shim"
+
+// RehashResult describes the shims written by Rehash
+type RehashResult struct {
+	Dir     string
+	Tools   []string
+	Removed []string
+}
+
+// ShimDir returns the directory shims are written to, next to config.json
+func ShimDir() (string, error) {
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), constants.DEFAULT_SHIMS_DIR), nil
+}
+
+// ShimsEnabled reports whether 'jenv rehash' has created the shim directory
+func ShimsEnabled() bool {
+	dir, err := ShimDir()
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
+
+// Rehash writes one shim for every executable found in the bin/ directory of any
+// registered JDK, and removes shims for tools no JDK provides any more
+func Rehash() (RehashResult, error) {
+	dir, err := ShimDir()
+	if err != nil {
+		return RehashResult{}, err
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return RehashResult{}, fmt.Errorf("无法确定 jenv 可执行文件路径: %v", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(self); err == nil {
+		self = resolved
+	}
+	return rehashIn(dir, self, cfg.Jdks)
+}
+
+func rehashIn(dir, self string, jdks map[string]config.JDK) (RehashResult, error) {
+	result := RehashResult{Dir: dir}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return result, fmt.Errorf("创建 shim 目录失败: %v", err)
+	}
+
+	tools := make(map[string]bool)
+	for _, jdk := range jdks {
+		for _, tool := range jdkTools(jdk.Path) {
+			tools[tool] = true
+		}
+	}
+
+	wanted := make(map[string]bool, len(tools))
+	for tool := range tools {
+		name := shimFileName(tool)
+		wanted[name] = true
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(shimScript(self, tool)), 0755); err != nil {
+			return result, fmt.Errorf("写入 shim %s 失败: %v", name, err)
+		}
+		result.Tools = append(result.Tools, tool)
+	}
+	sort.Strings(result.Tools)
+
+	// 删除已不存在于任何 JDK 中的工具的 shim
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return result, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || wanted[entry.Name()] {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil || !strings.Contains(string(data), shimMarker) {
+			continue
+		}
+		if err := os.Remove(path); err == nil {
+			result.Removed = append(result.Removed, entry.Name())
+		}
+	}
+	sort.Strings(result.Removed)
+	return result, nil
+}
+
+// jdkTools lists the executables in the bin/ directory of a Java home.
+// On Windows the .exe suffix is dropped.
+func jdkTools(home string) []string {
+	entries, err := os.ReadDir(filepath.Join(home, "bin"))
+	if err != nil {
+		return nil
+	}
+	var tools []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if runtime.GOOS == "windows" {
+			if !strings.EqualFold(filepath.Ext(name), ".exe") {
+				continue
+			}
+			name = strings.TrimSuffix(name, filepath.Ext(name))
+		} else {
+			info, err := os.Stat(filepath.Join(home, "bin", name))
+			if err != nil || info.IsDir() || info.Mode().Perm()&0111 == 0 {
+				continue
+			}
+		}
+		if validToolName(name) {
+			tools = append(tools, name)
+		}
+	}
+	return tools
+}
+
+// validToolName keeps names that are safe to embed in a shim script
+func validToolName(name string) bool {
+	if name == "" || strings.HasPrefix(name, ".") {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+func shimFileName(tool string) string {
+	if runtime.GOOS == "windows" {
+		return tool + ".cmd"
+	}
+	return tool
+}
+
+// shimScript returns a script that re-enters jenv through the hidden shim command
+func shimScript(self, tool string) string {
+	if runtime.GOOS == "windows" {
+		return fmt.Sprintf("@echo off\r\nrem %s, generated by 'jenv rehash'\r\n\"%s\" shim %s %%*\r\nexit /b %%ERRORLEVEL%%\r\n", shimMarker, self, tool)
+	}
+	return fmt.Sprintf("#!/bin/sh\n# %s, generated by 'jenv rehash'\nexec '%s' shim %s \"$@\"\n", shimMarker, strings.ReplaceAll(self, "'", `'\''`), tool)
+}
+
+// ResolveTool returns the real executable of tool in the JDK in effect for dir
+func ResolveTool(tool, dir string, getenv func(string) string) (string, Active, error) {
+	active, err := ResolveActive(dir, getenv)
+	if err != nil {
+		return "", active, err
+	}
+	path, err := toolPath(tool, active)
+	return path, active, err
+}
+
+func toolPath(tool string, active Active) (string, error) {
+	if !validToolName(tool) {
+		return "", fmt.Errorf("%w: invalid tool name %q", ErrToolNotFound, tool)
+	}
+	name := tool
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	path := filepath.Join(active.JDK.Path, "bin", name)
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return "", fmt.Errorf("%w: %s is not in %s (selected by %s)", ErrToolNotFound, tool, active.JDK.Name, active.Source)
+	}
+	return path, nil
+}
+
+// ToolEnv returns environ with JAVA_HOME pointing at home
+func ToolEnv(environ []string, home string) []string {
+	env := make([]string, 0, len(environ)+1)
+	for _, kv := range environ {
+		if key, _, _ := strings.Cut(kv, "="); envKeyIs(key, "JAVA_HOME") {
+			continue
+		}
+		env = append(env, kv)
+	}
+	return append(env, "JAVA_HOME="+home)
+}
@@ -0,0 +1,91 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+func TestRehash(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shim 脚本格式不同")
+	}
+	root := t.TempDir()
+	jdk17 := filepath.Join(root, "jdk-17")
+	jdk21 := filepath.Join(root, "jdk-21")
+	makeRuntime(t, jdk17, true, "bin/jar", "bin/libjli.so.txt")
+	makeRuntime(t, jdk21, true, "bin/jshell")
+	// 不可执行的文件不生成 shim
+	if err := os.Chmod(filepath.Join(jdk17, "bin", "libjli.so.txt"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	shims := filepath.Join(root, "shims")
+	if err := os.MkdirAll(shims, 0755); err != nil {
+		t.Fatal(err)
+	}
+	stale := filepath.Join(shims, "jconsole")
+	os.WriteFile(stale, []byte("#!/bin/sh\n# "+shimMarker+"\n"), 0755)
+	foreign := filepath.Join(shims, "mvn")
+	os.WriteFile(foreign, []byte("#!/bin/sh\n"), 0755)
+
+	jdks := map[string]config.JDK{
+		"jdk17": {Name: "jdk17", Path: jdk17},
+		"jdk21": {Name: "jdk21", Path: jdk21},
+	}
+	result, err := rehashIn(shims, "/usr/local/bin/jenv", jdks)
+	if err != nil {
+		t.Fatalf("rehash 失败: %v", err)
+	}
+
+	if got := strings.Join(result.Tools, ","); got != "jar,java,javac,jshell" {
+		t.Errorf("工具列表错误: %s", got)
+	}
+	if len(result.Removed) != 1 || result.Removed[0] != "jconsole" {
+		t.Errorf("应只删除过期的 shim，实际 %v", result.Removed)
+	}
+	if _, err := os.Stat(foreign); err != nil {
+		t.Error("不应删除非 jenv 生成的文件")
+	}
+
+	data, err := os.ReadFile(filepath.Join(shims, "javac"))
+	if err != nil {
+		t.Fatalf("读取 shim 失败: %v", err)
+	}
+	if !strings.Contains(string(data), "exec '/usr/local/bin/jenv' shim javac \"$@\"") {
+		t.Errorf("shim 内容错误:\n%s", data)
+	}
+}
+
+func TestToolPath(t *testing.T) {
+	home := filepath.Join(t.TempDir(), "jdk-17")
+	makeRuntime(t, home, true)
+	active := Active{Origin: OriginFile, Source: ".java-version:1"}
+	active.JDK = config.JDK{Name: "jdk17", Path: home}
+
+	if _, err := toolPath("javac", active); err != nil {
+		t.Errorf("应找到 javac: %v", err)
+	}
+	for _, tool := range []string{"jshell", "../javac", ""} {
+		if _, err := toolPath(tool, active); err == nil {
+			t.Errorf("期望 %q 查找失败", tool)
+		}
+	}
+}
+
+func TestToolEnv(t *testing.T) {
+	env := ToolEnv([]string{"PATH=/usr/bin", "JAVA_HOME=/old", "HOME=/root"}, "/opt/jdk17")
+	var homes []string
+	for _, kv := range env {
+		if strings.HasPrefix(kv, "JAVA_HOME=") {
+			homes = append(homes, kv)
+		}
+	}
+	if len(homes) != 1 || homes[0] != "JAVA_HOME=/opt/jdk17" {
+		t.Errorf("JAVA_HOME 设置错误: %v", env)
+	}
+}
@@ -0,0 +1,141 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+// 构建工具和 IDE 自带或下载的运行时来源。普通扫描会跳过这些目录，
+// jenv scan --include-tooling 只在这些已知位置中查找。
+const (
+	ToolingGradle        = "gradle"
+	ToolingJetBrains     = "jetbrains"
+	ToolingAndroidStudio = "android-studio"
+	ToolingCoursier      = "coursier"
+)
+
+// toolingDepth limits how far below a tooling root a Java home is looked for,
+// e.g. ~/.gradle/jdks/<toolchain>/<jdk>.jdk/Contents/Home
+const toolingDepth = 5
+
+// ToolingRoot is a location where a tool keeps runtimes; Pattern may contain globs
+type ToolingRoot struct {
+	Source  string
+	Pattern string
+}
+
+// ToolingRoots returns the tooling locations for this machine
+func ToolingRoots() []ToolingRoot {
+	home, _ := os.UserHomeDir()
+	return toolingRoots(runtime.GOOS, home, os.Getenv)
+}
+
+func toolingRoots(goos, home string, getenv func(string) string) []ToolingRoot {
+	join := filepath.Join
+	var roots []ToolingRoot
+	add := func(source string, patterns ...string) {
+		for _, p := range patterns {
+			roots = append(roots, ToolingRoot{Source: source, Pattern: p})
+		}
+	}
+
+	// Gradle toolchains 自动下载的 JDK
+	add(ToolingGradle, join(envOr(getenv, "GRADLE_USER_HOME", join(home, ".gradle")), "jdks"))
+
+	// Android Studio 要排在 JetBrains 之前，macOS 上两者都匹配 *.app
+	switch goos {
+	case "windows":
+		programFiles := envOr(getenv, "ProgramFiles", `C:\Program Files`)
+		localAppData := envOr(getenv, "LOCALAPPDATA", join(home, "AppData", "Local"))
+		add(ToolingAndroidStudio,
+			join(programFiles, "Android", "Android Studio*", "jbr"),
+			join(programFiles, "Android", "Android Studio*", "jre"))
+		add(ToolingJetBrains,
+			join(programFiles, "JetBrains", "*", "jbr"),
+			join(localAppData, "Programs", "*", "jbr"),
+			join(localAppData, "JetBrains", "Toolbox", "apps", "*", "jbr"),
+			join(localAppData, "JetBrains", "Toolbox", "apps", "*", "ch-*", "*", "jbr"))
+		add(ToolingCoursier, envOr(getenv, "COURSIER_JVM_CACHE", join(localAppData, "Coursier", "cache", "jvm")))
+	case "darwin":
+		for _, apps := range []string{"/Applications", join(home, "Applications")} {
+			add(ToolingAndroidStudio,
+				join(apps, "Android Studio*.app", "Contents", "jbr"),
+				join(apps, "Android Studio*.app", "Contents", "jre"))
+			add(ToolingJetBrains, join(apps, "*.app", "Contents", "jbr"))
+		}
+		add(ToolingJetBrains,
+			join(home, "Library", "Application Support", "JetBrains", "Toolbox", "apps", "*", "*.app", "Contents", "jbr"),
+			join(home, "Library", "Application Support", "JetBrains", "Toolbox", "apps", "*", "ch-*", "*", "*.app", "Contents", "jbr"))
+		add(ToolingCoursier, envOr(getenv, "COURSIER_JVM_CACHE", join(home, "Library", "Caches", "Coursier", "jvm")))
+	default:
+		add(ToolingAndroidStudio,
+			"/opt/android-studio*/jbr", "/opt/android-studio*/jre",
+			"/usr/local/android-studio*/jbr", "/snap/android-studio/current/jbr",
+			join(home, "android-studio*", "jbr"), join(home, "android-studio*", "jre"))
+		add(ToolingJetBrains,
+			"/opt/*idea*/jbr", "/opt/jetbrains/*/jbr", "/snap/intellij-idea-*/current/jbr",
+			join(home, ".local", "share", "JetBrains", "Toolbox", "apps", "*", "jbr"),
+			join(home, ".local", "share", "JetBrains", "Toolbox", "apps", "*", "ch-*", "*", "jbr"))
+		cache := envOr(getenv, "XDG_CACHE_HOME", join(home, ".cache"))
+		add(ToolingCoursier, envOr(getenv, "COURSIER_JVM_CACHE", join(cache, "coursier", "jvm")))
+	}
+	return roots
+}
+
+// ScanTooling looks for runtimes in the tooling locations of this machine. JREs are
+// always reported, since the JetBrains Runtime and Android Studio's jbr have no javac.
+func ScanTooling() ScanResult {
+	return scanToolingRoots(ToolingRoots(), getExistingJDKPaths())
+}
+
+func scanToolingRoots(roots []ToolingRoot, existingPaths map[string]bool) ScanResult {
+	start := time.Now()
+	var result ScanResult
+	seen := make(map[string]bool)
+	for _, root := range roots {
+		matches, _ := filepath.Glob(root.Pattern)
+		sort.Strings(matches)
+		for _, dir := range matches {
+			walkTooling(dir, root.Source, 1, existingPaths, seen, &result)
+		}
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// walkTooling checks dir and its subdirectories for Java homes. Unlike general scans
+// no directory names are skipped, these locations only hold runtimes.
+func walkTooling(dir, source string, depth int, existingPaths, seen map[string]bool, result *ScanResult) {
+	if seen[dir] {
+		return
+	}
+	seen[dir] = true
+	result.Scanned++
+
+	if isPathExcluded(dir, existingPaths) {
+		result.Excluded++
+		return
+	}
+	if config.ValidateJavaPath(dir) || isRuntimeHome(dir) {
+		result.JDKs = append(result.JDKs, JDK{Path: dir, Name: filepath.Base(dir), Kind: DetectKind(dir), Source: source})
+		return
+	}
+	if depth >= toolingDepth {
+		return
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		result.Skipped++
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			walkTooling(filepath.Join(dir, entry.Name()), source, depth+1, existingPaths, seen, result)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package java
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+func TestScanTooling(t *testing.T) {
+	home := t.TempDir()
+	// Gradle toolchain 目录下还有一层 JDK 目录
+	makeRuntime(t, filepath.Join(home, ".gradle/jdks/eclipse_adoptium-17-amd64-linux/jdk-17.0.9+9"), true, "release")
+	makeRuntime(t, filepath.Join(home, ".gradle/jdks/azul_systems-21-aarch64-mac/zulu-21.jdk/Contents/Home"), true, "release")
+	// 捆绑的 JetBrains Runtime 没有 javac
+	makeRuntime(t, filepath.Join(home, ".local/share/JetBrains/Toolbox/apps/intellij-idea-ultimate/jbr"), false, "release")
+	makeRuntime(t, filepath.Join(home, "android-studio/jbr"), false, "release")
+	makeRuntime(t, filepath.Join(home, ".cache/coursier/jvm/adoptium@1.17.0"), true, "release")
+	makeRuntime(t, filepath.Join(home, ".cache/coursier/jvm/registered"), true, "release")
+
+	roots := toolingRoots("linux", home, func(string) string { return "" })
+	existing := map[string]bool{}
+	registered, _ := filepath.Abs(filepath.Join(home, ".cache/coursier/jvm/registered"))
+	existing[strings.ToLower(registered)] = true
+
+	result := scanToolingRoots(roots, existing)
+	got := make(map[string]JDK)
+	for _, jdk := range result.JDKs {
+		rel, _ := filepath.Rel(home, jdk.Path)
+		got[filepath.ToSlash(rel)] = jdk
+	}
+	want := map[string][2]string{
+		".gradle/jdks/eclipse_adoptium-17-amd64-linux/jdk-17.0.9+9":          {ToolingGradle, config.KindJDK},
+		".gradle/jdks/azul_systems-21-aarch64-mac/zulu-21.jdk/Contents/Home": {ToolingGradle, config.KindJDK},
+		".local/share/JetBrains/Toolbox/apps/intellij-idea-ultimate/jbr":     {ToolingJetBrains, config.KindJRE},
+		"android-studio/jbr":                  {ToolingAndroidStudio, config.KindJRE},
+		".cache/coursier/jvm/adoptium@1.17.0": {ToolingCoursier, config.KindJDK},
+	}
+	if len(got) != len(want) {
+		t.Errorf("期望找到 %d 个运行时，实际 %d: %v", len(want), len(got), got)
+	}
+	for path, w := range want {
+		jdk, ok := got[path]
+		if !ok {
+			t.Errorf("未找到 %s", path)
+			continue
+		}
+		if jdk.Source != w[0] || jdk.Kind != w[1] {
+			t.Errorf("%s: 来源 %q 类型 %q，期望 %q %q", path, jdk.Source, jdk.Kind, w[0], w[1])
+		}
+	}
+	if result.Excluded != 1 {
+		t.Errorf("已注册的运行时应被排除，Excluded = %d", result.Excluded)
+	}
+}
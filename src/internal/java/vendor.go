@@ -0,0 +1,138 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Known JDK distributions
+const (
+	VendorTemurin   = "temurin"
+	VendorZulu      = "zulu"
+	VendorCorretto  = "corretto"
+	VendorGraalVM   = "graalvm"
+	VendorLiberica  = "liberica"
+	VendorSemeru    = "semeru"
+	VendorOracle    = "oracle"
+	VendorJBR       = "jbr"
+	VendorMicrosoft = "microsoft"
+	VendorSAP       = "sapmachine"
+	VendorOpenJDK   = "openjdk"
+	VendorUnknown   = "unknown"
+)
+
+// vendorAliases maps the spellings found in release files, directory names and
+// other tools' identifiers onto a distribution. Order matters: the first hit wins.
+var vendorAliases = []struct {
+	alias  string
+	vendor string
+}{
+	{"graalvm", VendorGraalVM},
+	{"graal", VendorGraalVM},
+	{"temurin", VendorTemurin},
+	{"adoptium", VendorTemurin},
+	{"adoptopenjdk", VendorTemurin},
+	{"adopt", VendorTemurin},
+	{"zulu", VendorZulu},
+	{"azul", VendorZulu},
+	{"corretto", VendorCorretto},
+	{"amazon", VendorCorretto},
+	{"liberica", VendorLiberica},
+	{"bellsoft", VendorLiberica},
+	{"semeru", VendorSemeru},
+	{"openj9", VendorSemeru},
+	{"ibm", VendorSemeru},
+	{"international business machines", VendorSemeru},
+	{"jbr", VendorJBR},
+	{"jetbrains", VendorJBR},
+	{"microsoft", VendorMicrosoft},
+	{"sapmachine", VendorSAP},
+	{"sap se", VendorSAP},
+	{"oracle", VendorOracle},
+	{"openjdk", VendorOpenJDK},
+	{"red hat", VendorOpenJDK},
+	{"debian", VendorOpenJDK},
+	{"ubuntu", VendorOpenJDK},
+}
+
+// NormalizeVendor maps a user supplied vendor name or alias (e.g. "adoptium", "amazon")
+// onto a known distribution. Unknown names are returned lower-cased.
+func NormalizeVendor(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return ""
+	}
+	for _, a := range vendorAliases {
+		if name == a.alias {
+			return a.vendor
+		}
+	}
+	return name
+}
+
+// DetectVendor works out the distribution of the Java home at path
+func DetectVendor(home string) string {
+	rel, _ := ReadRelease(home)
+	return classifyVendor(home, rel)
+}
+
+// classifyVendor combines marker files, release metadata and the directory name,
+// from the most to the least reliable signal
+func classifyVendor(home string, rel Release) string {
+	// GraalVM 的 IMPLEMENTOR 可能是 Oracle，需要优先检查标记文件
+	if rel.Values["GRAALVM_VERSION"] != "" || fileExists(filepath.Join(home, "lib", "svm")) {
+		return VendorGraalVM
+	}
+
+	commercial := strings.EqualFold(rel.Values["BUILD_TYPE"], "commercial")
+	for _, hint := range []string{rel.ImplementorVersion, rel.Implementor, directoryHint(home)} {
+		vendor := matchVendor(hint)
+		if vendor == VendorOracle && hint == rel.Implementor && !commercial {
+			// jdk.java.net 的 OpenJDK 构建同样标记为 Oracle Corporation
+			return VendorOpenJDK
+		}
+		if vendor != "" {
+			return vendor
+		}
+	}
+	if commercial {
+		return VendorOracle
+	}
+
+	// 无厂商信息但有 release 文件时，多为发行版自带的 OpenJDK
+	if rel.JavaVersion != "" {
+		return VendorOpenJDK
+	}
+	return VendorUnknown
+}
+
+// matchVendor looks for a known alias inside free-form text
+func matchVendor(text string) string {
+	text = strings.ToLower(text)
+	if text == "" || text == "n/a" {
+		return ""
+	}
+	for _, a := range vendorAliases {
+		if strings.Contains(text, a.alias) {
+			return a.vendor
+		}
+	}
+	return ""
+}
+
+// directoryHint returns the path components that usually carry a distribution name,
+// e.g. "zulu17.44.53-ca-jdk17.0.8.1-linux_x64" or "/Library/Java/JavaVirtualMachines/temurin-17.jdk/Contents/Home"
+func directoryHint(home string) string {
+	clean := filepath.Clean(home)
+	base := filepath.Base(clean)
+	if base == "Home" && filepath.Base(filepath.Dir(clean)) == "Contents" {
+		base = filepath.Base(filepath.Dir(filepath.Dir(clean)))
+	}
+	return base
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,121 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectVendor(t *testing.T) {
+	tests := []struct {
+		name     string
+		dir      string
+		release  string
+		markers  []string
+		expected string
+	}{
+		{
+			name:     "Temurin",
+			dir:      "jdk-17.0.9+9",
+			release:  "IMPLEMENTOR=\"Eclipse Adoptium\"\nIMPLEMENTOR_VERSION=\"Temurin-17.0.9+9\"\nJAVA_VERSION=\"17.0.9\"\n",
+			expected: VendorTemurin,
+		},
+		{
+			name:     "Zulu",
+			dir:      "jdk17",
+			release:  "IMPLEMENTOR=\"Azul Systems, Inc.\"\nIMPLEMENTOR_VERSION=\"Zulu17.44+53-CA\"\nJAVA_VERSION=\"17.0.8.1\"\n",
+			expected: VendorZulu,
+		},
+		{
+			name:     "Corretto",
+			dir:      "jdk17b",
+			release:  "IMPLEMENTOR=\"Amazon.com Inc.\"\nIMPLEMENTOR_VERSION=\"Corretto-17.0.9.8.1\"\nJAVA_VERSION=\"17.0.9\"\n",
+			expected: VendorCorretto,
+		},
+		{
+			name:     "Oracle GraalVM 通过标记文件识别",
+			dir:      "graal",
+			release:  "IMPLEMENTOR=\"Oracle Corporation\"\nJAVA_VERSION=\"21.0.1\"\n",
+			markers:  []string{"lib/svm"},
+			expected: VendorGraalVM,
+		},
+		{
+			name:     "Oracle JDK",
+			dir:      "jdk-21",
+			release:  "IMPLEMENTOR=\"Oracle Corporation\"\nJAVA_VERSION=\"21.0.1\"\nBUILD_TYPE=\"commercial\"\n",
+			expected: VendorOracle,
+		},
+		{
+			name:     "jdk.java.net OpenJDK",
+			dir:      "jdk-22",
+			release:  "IMPLEMENTOR=\"Oracle Corporation\"\nJAVA_VERSION=\"22\"\n",
+			expected: VendorOpenJDK,
+		},
+		{
+			name:     "Semeru",
+			dir:      "jdk-17.0.9+9",
+			release:  "IMPLEMENTOR=\"IBM Corporation\"\nJAVA_VERSION=\"17.0.9\"\n",
+			expected: VendorSemeru,
+		},
+		{
+			name:     "JetBrains Runtime",
+			dir:      "jbr",
+			release:  "IMPLEMENTOR=\"JetBrains s.r.o.\"\nJAVA_VERSION=\"17.0.9\"\n",
+			expected: VendorJBR,
+		},
+		{
+			name:     "仅目录名",
+			dir:      "bellsoft-jdk11.0.21+10",
+			expected: VendorLiberica,
+		},
+		{
+			name:     "macOS Contents/Home",
+			dir:      "zulu-17.jdk/Contents/Home",
+			expected: VendorZulu,
+		},
+		{
+			name:     "无任何信息",
+			dir:      "java",
+			expected: VendorUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := filepath.Join(t.TempDir(), filepath.FromSlash(tt.dir))
+			if err := os.MkdirAll(home, 0755); err != nil {
+				t.Fatalf("创建目录失败: %v", err)
+			}
+			if tt.release != "" {
+				writeRelease(t, home, tt.release)
+			}
+			for _, marker := range tt.markers {
+				if err := os.MkdirAll(filepath.Join(home, filepath.FromSlash(marker)), 0755); err != nil {
+					t.Fatalf("创建标记文件失败: %v", err)
+				}
+			}
+
+			if got := DetectVendor(home); got != tt.expected {
+				t.Errorf("期望 %s，实际 %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeVendor(t *testing.T) {
+	tests := map[string]string{
+		"Temurin":    VendorTemurin,
+		"adoptium":   VendorTemurin,
+		"amazon":     VendorCorretto,
+		"azul":       VendorZulu,
+		"openj9":     VendorSemeru,
+		" ORACLE ":   VendorOracle,
+		"dragonwell": "dragonwell",
+		"":           "",
+	}
+	for input, expected := range tests {
+		if got := NormalizeVendor(input); got != expected {
+			t.Errorf("NormalizeVendor(%q) = %q，期望 %q", input, got, expected)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package java
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+// Version is a parsed Java version string.
+//
+// Both the legacy scheme (1.8.0_392-b08) and the JEP 322 scheme
+// ($FEATURE.$INTERIM.$UPDATE.$PATCH[-$PRE][+$BUILD][-$OPT], e.g. 17.0.9+9-LTS, 21-ea+22)
+// are understood. Legacy versions are normalized so that 1.8.0_392 has feature 8 and update 392.
+type Version struct {
+	Raw     string
+	Numbers []int  // feature, interim, update, patch, ...
+	Pre     string // pre-release identifier, e.g. "ea"
+	Build   int    // build number, 0 when absent
+	Opt     string // optional build information, e.g. "LTS"
+}
+
+var (
+	ErrInvalidVersion = errors.New("invalid Java version")
+
+	// 旧版本号: 1.8.0_392-b08
+	legacyVersionPattern = regexp.MustCompile(`^1\.(\d+)(?:\.(\d+))?(?:_(\d+))?(?:-(?:b(\d+)|([a-zA-Z][\w.-]*)))?$`)
+	// JEP 322 版本号: 17.0.9+9-LTS, 21-ea+22, 22.0.1.0.1
+	modernVersionPattern = regexp.MustCompile(`^(\d+(?:\.\d+)*)(?:-([a-zA-Z0-9]+))?(?:\+(\d+))?(?:-([\w.-]+))?$`)
+	// 从目录名等文本中猜测版本号
+	embeddedVersionPattern = regexp.MustCompile(`\d+(?:\.\d+)*(?:_\d+)?`)
+)
+
+// ParseVersion parses a Java version string
+func ParseVersion(s string) (Version, error) {
+	raw := strings.TrimSpace(s)
+	v := Version{Raw: raw}
+
+	if m := legacyVersionPattern.FindStringSubmatch(raw); m != nil {
+		v.Numbers = []int{atoi(m[1]), atoi(m[2]), atoi(m[3])}
+		v.Build = atoi(m[4])
+		v.Pre = strings.ToLower(m[5])
+		v.Numbers = trimZeros(v.Numbers)
+		return v, nil
+	}
+
+	m := modernVersionPattern.FindStringSubmatch(raw)
+	if m == nil {
+		return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+	}
+	for _, part := range strings.Split(m[1], ".") {
+		v.Numbers = append(v.Numbers, atoi(part))
+	}
+	v.Numbers = trimZeros(v.Numbers)
+	v.Pre = strings.ToLower(m[2])
+	v.Build = atoi(m[3])
+	v.Opt = m[4]
+	// 17.0.9-LTS 这种没有构建号的写法，LTS 是附加信息而不是预发布标识
+	if strings.EqualFold(v.Pre, "lts") && v.Opt == "" {
+		v.Opt, v.Pre = "LTS", ""
+	}
+	return v, nil
+}
+
+// MustParseVersion is like ParseVersion but panics on error
+func MustParseVersion(s string) Version {
+	v, err := ParseVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Feature returns the feature release number, e.g. 8 for 1.8.0_392 and 17 for 17.0.9
+func (v Version) Feature() int { return v.number(0) }
+
+// Major is an alias of Feature, matching the name most people use
+func (v Version) Major() int { return v.Feature() }
+
+// Interim returns the interim release counter
+func (v Version) Interim() int { return v.number(1) }
+
+// Update returns the update release counter (the "_392" of 1.8.0_392)
+func (v Version) Update() int { return v.number(2) }
+
+// Patch returns the emergency patch release counter
+func (v Version) Patch() int { return v.number(3) }
+
+// IsEA reports whether the version is a pre-release (early access) build
+func (v Version) IsEA() bool { return v.Pre != "" }
+
+// IsLTS reports whether the feature release is a long-term-support release (8, 11, 17, 21, 25, ...)
+func (v Version) IsLTS() bool {
+	f := v.Feature()
+	return f == 8 || f == 11 || (f >= 17 && (f-17)%4 == 0)
+}
+
+// IsZero reports whether v holds no version
+func (v Version) IsZero() bool { return len(v.Numbers) == 0 }
+
+func (v Version) number(i int) int {
+	if i < len(v.Numbers) {
+		return v.Numbers[i]
+	}
+	return 0
+}
+
+// Compare returns -1, 0 or 1. Version numbers are compared first, then a
+// pre-release sorts before the matching release, then the build number.
+func (v Version) Compare(o Version) int {
+	n := len(v.Numbers)
+	if len(o.Numbers) > n {
+		n = len(o.Numbers)
+	}
+	for i := 0; i < n; i++ {
+		if c := compareInt(v.number(i), o.number(i)); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case v.Pre == "" && o.Pre != "":
+		return 1
+	case v.Pre != "" && o.Pre == "":
+		return -1
+	case v.Pre != o.Pre:
+		return strings.Compare(v.Pre, o.Pre)
+	}
+	return compareInt(v.Build, o.Build)
+}
+
+// Less reports whether v sorts before o
+func (v Version) Less(o Version) bool { return v.Compare(o) < 0 }
+
+// String returns the canonical JEP 322 form of the version
+func (v Version) String() string {
+	if v.IsZero() {
+		return ""
+	}
+	parts := make([]string, len(v.Numbers))
+	for i, n := range v.Numbers {
+		parts[i] = strconv.Itoa(n)
+	}
+	s := strings.Join(parts, ".")
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	if v.Build > 0 {
+		s += "+" + strconv.Itoa(v.Build)
+	}
+	if v.Opt != "" {
+		s += "-" + v.Opt
+	}
+	return s
+}
+
+// JDKVersion returns the version of a registered JDK, preferring the
+// runtime version from its release file and falling back to its directory name
+func JDKVersion(jdk config.JDK) (Version, bool) {
+	for _, s := range []string{jdk.JavaRuntimeVersion, jdk.JavaVersion} {
+		if s == "" {
+			continue
+		}
+		if v, err := ParseVersion(s); err == nil {
+			return v, true
+		}
+	}
+	return guessVersion(directoryHint(jdk.Path))
+}
+
+// guessVersion extracts the first version-looking token from text such as "jdk1.8.0_292" or "jdk-11.0.12"
+func guessVersion(text string) (Version, bool) {
+	token := embeddedVersionPattern.FindString(text)
+	if token == "" {
+		return Version{}, false
+	}
+	v, err := ParseVersion(token)
+	if err != nil || v.Feature() == 0 {
+		return Version{}, false
+	}
+	return v, true
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// trimZeros drops trailing zero components so that 17 and 17.0.0 are the same version
+func trimZeros(numbers []int) []int {
+	for len(numbers) > 1 && numbers[len(numbers)-1] == 0 {
+		numbers = numbers[:len(numbers)-1]
+	}
+	return numbers
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,127 @@
+package java
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		input   string
+		feature int
+		update  int
+		patch   int
+		build   int
+		ea      bool
+		opt     string
+		str     string
+	}{
+		{input: "1.8.0_392", feature: 8, update: 392, str: "8.0.392"},
+		{input: "1.8.0_392-b08", feature: 8, update: 392, build: 8, str: "8.0.392+8"},
+		{input: "1.7.0", feature: 7, str: "7"},
+		{input: "11.0.21+9", feature: 11, update: 21, build: 9, str: "11.0.21+9"},
+		{input: "17.0.9+9-LTS", feature: 17, update: 9, build: 9, opt: "LTS", str: "17.0.9+9-LTS"},
+		{input: "17.0.9-LTS", feature: 17, update: 9, opt: "LTS", str: "17.0.9-LTS"},
+		{input: "21-ea+22", feature: 21, build: 22, ea: true, str: "21-ea+22"},
+		{input: "22.0.1.0.1", feature: 22, update: 1, str: "22.0.1.0.1"},
+		{input: "17.0.8.1", feature: 17, update: 8, patch: 1, str: "17.0.8.1"},
+		{input: "21", feature: 21, str: "21"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			v, err := ParseVersion(tt.input)
+			if err != nil {
+				t.Fatalf("解析失败: %v", err)
+			}
+			if v.Feature() != tt.feature || v.Major() != tt.feature {
+				t.Errorf("feature 期望 %d，实际 %d", tt.feature, v.Feature())
+			}
+			if v.Update() != tt.update {
+				t.Errorf("update 期望 %d，实际 %d", tt.update, v.Update())
+			}
+			if v.Patch() != tt.patch {
+				t.Errorf("patch 期望 %d，实际 %d", tt.patch, v.Patch())
+			}
+			if v.Build != tt.build {
+				t.Errorf("build 期望 %d，实际 %d", tt.build, v.Build)
+			}
+			if v.IsEA() != tt.ea {
+				t.Errorf("EA 期望 %v，实际 %v", tt.ea, v.IsEA())
+			}
+			if v.Opt != tt.opt {
+				t.Errorf("opt 期望 %q，实际 %q", tt.opt, v.Opt)
+			}
+			if v.String() != tt.str {
+				t.Errorf("String() 期望 %q，实际 %q", tt.str, v.String())
+			}
+		})
+	}
+}
+
+func TestParseVersionInvalid(t *testing.T) {
+	for _, input := range []string{"", "jdk17", "latest", "17.x"} {
+		if _, err := ParseVersion(input); err == nil {
+			t.Errorf("期望 %q 解析失败", input)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	ordered := []string{
+		"1.7.0_80",
+		"1.8.0_292",
+		"1.8.0_392-b08",
+		"11.0.21+9",
+		"17-ea+3",
+		"17",
+		"17.0.9+9-LTS",
+		"17.0.9+11",
+		"17.0.10",
+		"21-ea+22",
+		"21.0.1",
+		"22.0.1.0.1",
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		a, b := MustParseVersion(ordered[i-1]), MustParseVersion(ordered[i])
+		if a.Compare(b) >= 0 || b.Compare(a) <= 0 {
+			t.Errorf("期望 %s < %s", ordered[i-1], ordered[i])
+		}
+	}
+
+	if MustParseVersion("17.0.0").Compare(MustParseVersion("17")) != 0 {
+		t.Error("期望 17.0.0 == 17")
+	}
+}
+
+func TestJDKVersion(t *testing.T) {
+	jdks := []config.JDK{
+		{Name: "jdk17", Path: "/opt/jdk17", JavaRuntimeVersion: "17.0.9+9"},
+		{Name: "jdk11", Path: "/opt/jdk11", JavaVersion: "11.0.21"},
+		{Name: "jdk8", Path: "/opt/jdk1.8.0_292"},
+		{Name: "misc", Path: "/opt/java"},
+	}
+
+	sort.SliceStable(jdks, func(i, j int) bool {
+		vi, okI := JDKVersion(jdks[i])
+		vj, okJ := JDKVersion(jdks[j])
+		if okI != okJ {
+			return okI
+		}
+		return vi.Less(vj)
+	})
+
+	var names []string
+	for _, jdk := range jdks {
+		names = append(names, jdk.Name)
+	}
+	expected := []string{"jdk8", "jdk11", "jdk17", "misc"}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Fatalf("排序结果错误: %v", names)
+		}
+	}
+}
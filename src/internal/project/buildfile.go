@@ -0,0 +1,288 @@
+package project
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Build files the required Java level is read from
+const (
+	PomFileName             = "pom.xml"
+	GradleKotlinFileName    = "build.gradle.kts"
+	GradleGroovyFileName    = "build.gradle"
+	GradleDaemonJVMFileName = "gradle/gradle-daemon-jvm.properties"
+)
+
+// ErrNoRequirement is returned when no build file between a directory and the
+// filesystem root states a Java level
+var ErrNoRequirement = errors.New("no build file states the required Java version")
+
+// Requirement is the Java level a build file asks for
+type Requirement struct {
+	Level  int    // Java 特性版本，如 8、17
+	Vendor string // 发行版，仅 gradle-daemon-jvm.properties 可指定
+	Exact  bool   // 工具链要求恰好该版本；否则更高版本同样可以编译
+	Key    string // 声明版本的设置，如 maven.compiler.release
+	File   string
+	Line   int
+}
+
+func (r Requirement) String() string {
+	return fmt.Sprintf("%s:%d", r.File, r.Line)
+}
+
+// buildFiles in order of precedence within one directory: the JVM Gradle asks to
+// run on, then the Gradle toolchain, then Maven
+var buildFiles = []struct {
+	name string
+	read func(string) (Requirement, error)
+}{
+	{GradleDaemonJVMFileName, ReadGradleDaemonJVM},
+	{GradleKotlinFileName, ReadGradleBuild},
+	{GradleGroovyFileName, ReadGradleBuild},
+	{PomFileName, ReadPom},
+}
+
+// DetectRequirement walks up from dir and returns the Java level stated by the
+// nearest build file. Build files that do not state one, such as a module pom
+// inheriting from its parent, are passed over.
+func DetectRequirement(dir string) (Requirement, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return Requirement{}, err
+	}
+	for {
+		for _, bf := range buildFiles {
+			path := filepath.Join(dir, filepath.FromSlash(bf.name))
+			if info, err := os.Stat(path); err != nil || info.IsDir() {
+				continue
+			}
+			req, err := bf.read(path)
+			if errors.Is(err, ErrNoRequirement) {
+				continue
+			}
+			return req, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return Requirement{}, ErrNoRequirement
+		}
+		dir = parent
+	}
+}
+
+// ReadGradleDaemonJVM reads toolchainVersion and toolchainVendor from gradle-daemon-jvm.properties
+func ReadGradleDaemonJVM(path string) (Requirement, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Requirement{}, err
+	}
+	defer f.Close()
+
+	req := Requirement{File: path, Exact: true}
+	vendor := ""
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || text[0] == '#' || text[0] == '!' {
+			continue
+		}
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "toolchainVersion":
+			level, err := parseJavaLevel(value)
+			if err != nil {
+				return Requirement{}, fmt.Errorf("%s:%d: %v", path, line, err)
+			}
+			req.Level, req.Key, req.Line = level, key, line
+		case "toolchainVendor":
+			vendor = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Requirement{}, err
+	}
+	if req.Level == 0 {
+		return Requirement{}, ErrNoRequirement
+	}
+	req.Vendor = vendor
+	return req, nil
+}
+
+var (
+	// languageVersion = JavaLanguageVersion.of(17)、languageVersion.set(JavaLanguageVersion.of("17"))
+	gradleToolchainPattern = regexp.MustCompile(`languageVersion.*JavaLanguageVersion\.of\(\s*["']?(\d+)["']?\s*\)`)
+	// kotlin { jvmToolchain(17) }
+	gradleJVMToolchainPattern = regexp.MustCompile(`\bjvmToolchain\(\s*(\d+)\s*\)`)
+	// sourceCompatibility = JavaVersion.VERSION_1_8、targetCompatibility = '11'、sourceCompatibility = 17
+	gradleCompatibilityPattern = regexp.MustCompile(`\b((?:source|target)Compatibility)\b\s*(?:=|\.set\()?\s*(?:JavaVersion\.VERSION_(\d+(?:_\d+)?)|JavaVersion\.toVersion\(\s*["']?([\d.]+)["']?\s*\)|["']?([\d.]+)["']?)`)
+)
+
+// ReadGradleBuild reads the Java toolchain language version of a Groovy or Kotlin
+// build script, falling back to the highest of source and target compatibility.
+// Scripts are matched line by line, not evaluated.
+func ReadGradleBuild(path string) (Requirement, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Requirement{}, err
+	}
+
+	var toolchain, compat Requirement
+	for i, text := range strings.Split(string(data), "\n") {
+		text = strings.TrimSpace(text)
+		if strings.HasPrefix(text, "//") || strings.HasPrefix(text, "*") || strings.HasPrefix(text, "/*") {
+			continue
+		}
+		if m := gradleToolchainPattern.FindStringSubmatch(text); m != nil && toolchain.Level == 0 {
+			level, _ := strconv.Atoi(m[1])
+			toolchain = Requirement{Level: level, Exact: true, Key: "java.toolchain.languageVersion", File: path, Line: i + 1}
+		} else if m := gradleJVMToolchainPattern.FindStringSubmatch(text); m != nil && toolchain.Level == 0 {
+			level, _ := strconv.Atoi(m[1])
+			toolchain = Requirement{Level: level, Exact: true, Key: "kotlin.jvmToolchain", File: path, Line: i + 1}
+		}
+		if m := gradleCompatibilityPattern.FindStringSubmatch(text); m != nil {
+			value := strings.ReplaceAll(m[2], "_", ".") + m[3] + m[4]
+			level, err := parseJavaLevel(value)
+			if err != nil {
+				return Requirement{}, fmt.Errorf("%s:%d: %v", path, i+1, err)
+			}
+			if level > compat.Level {
+				compat = Requirement{Level: level, Key: m[1], File: path, Line: i + 1}
+			}
+		}
+	}
+	if toolchain.Level > 0 {
+		return toolchain, nil
+	}
+	if compat.Level > 0 {
+		return compat, nil
+	}
+	return Requirement{}, ErrNoRequirement
+}
+
+// pomLevelKeys are the settings read from pom.xml, highest precedence first
+var pomLevelKeys = []string{"maven.compiler.release", "maven.compiler.target", "maven.compiler.source"}
+
+// ReadPom reads the compiler level of a Maven project: <release>, <target> or
+// <source> of the maven-compiler-plugin configuration, or the maven.compiler.*
+// properties. ${property} references to the pom's own properties are resolved.
+// A release wins over source and target, which count for the higher of the two.
+func ReadPom(path string) (Requirement, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Requirement{}, err
+	}
+
+	type setting struct {
+		value string
+		line  int
+	}
+	properties := make(map[string]setting)
+	plugin := make(map[string]setting)
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var stack []string
+	var text strings.Builder
+	inCompiler := false
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Requirement{}, fmt.Errorf("%s: %v", path, err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name.Local)
+			text.Reset()
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			value := strings.TrimSpace(text.String())
+			line := 1 + bytes.Count(data[:decoder.InputOffset()], []byte("\n"))
+			n := len(stack)
+			switch {
+			case n == 3 && stack[0] == "project" && stack[1] == "properties":
+				properties[t.Name.Local] = setting{value, line}
+			case n >= 2 && t.Name.Local == "artifactId" && stack[n-2] == "plugin" && value == "maven-compiler-plugin":
+				inCompiler = true
+			case n >= 2 && stack[n-2] == "configuration" && inCompiler && pluginConfigured(stack):
+				plugin["maven.compiler."+t.Name.Local] = setting{value, line}
+			case t.Name.Local == "plugin":
+				inCompiler = false
+			}
+			stack = stack[:n-1]
+			text.Reset()
+		}
+	}
+
+	resolve := func(value string) string {
+		for i := 0; i < 10 && strings.HasPrefix(value, "${") && strings.HasSuffix(value, "}"); i++ {
+			value = properties[value[2:len(value)-1]].value
+		}
+		return value
+	}
+	lookup := func(key string) (setting, bool) {
+		if s, ok := plugin[key]; ok && s.value != "" {
+			return s, true
+		}
+		s, ok := properties[key]
+		return s, ok && s.value != ""
+	}
+
+	var req Requirement
+	for _, key := range pomLevelKeys {
+		s, ok := lookup(key)
+		if !ok {
+			continue
+		}
+		level, err := parseJavaLevel(resolve(s.value))
+		if err != nil {
+			return Requirement{}, fmt.Errorf("%s:%d: %s: %v", path, s.line, key, err)
+		}
+		if key == "maven.compiler.release" {
+			return Requirement{Level: level, Key: key, File: path, Line: s.line}, nil
+		}
+		if level > req.Level {
+			req = Requirement{Level: level, Key: key, File: path, Line: s.line}
+		}
+	}
+	if req.Level == 0 {
+		return Requirement{}, ErrNoRequirement
+	}
+	return req, nil
+}
+
+// pluginConfigured reports whether the element path is the plugin-wide
+// configuration, not that of an execution
+func pluginConfigured(stack []string) bool {
+	n := len(stack)
+	return n >= 3 && stack[n-3] == "plugin"
+}
+
+// parseJavaLevel accepts levels as build tools write them: 1.8, 8, 17, 21.0
+func parseJavaLevel(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	major, _, _ := strings.Cut(strings.TrimPrefix(value, "1."), ".")
+	level, err := strconv.Atoi(major)
+	if err != nil || level <= 0 {
+		return 0, fmt.Errorf("invalid Java version %q", value)
+	}
+	return level, nil
+}
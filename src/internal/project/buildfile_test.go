@@ -0,0 +1,109 @@
+package project
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeBuildFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("创建目录失败: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("写入文件失败: %v", err)
+	}
+	return path
+}
+
+const parentPom = `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <properties>
+    <java.version>17</java.version>
+    <maven.compiler.source>1.8</maven.compiler.source>
+    <maven.compiler.target>11</maven.compiler.target>
+  </properties>
+  <build>
+    <plugins>
+      <plugin>
+        <groupId>org.apache.maven.plugins</groupId>
+        <artifactId>maven-compiler-plugin</artifactId>
+        <configuration>
+          <release>${java.version}</release>
+        </configuration>
+      </plugin>
+    </plugins>
+  </build>
+</project>
+`
+
+func TestReadPom(t *testing.T) {
+	dir := t.TempDir()
+	path := writeBuildFile(t, dir, PomFileName, parentPom)
+	req, err := ReadPom(path)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if req.Level != 17 || req.Key != "maven.compiler.release" || req.Line != 14 {
+		t.Errorf("期望插件 release 17，实际 %+v", req)
+	}
+
+	// 只有 source/target 时取较高者
+	writeBuildFile(t, dir, PomFileName, "<project><properties>\n<maven.compiler.source>1.8</maven.compiler.source>\n<maven.compiler.target>11</maven.compiler.target>\n</properties></project>")
+	if req, err := ReadPom(path); err != nil || req.Level != 11 || req.Line != 3 {
+		t.Errorf("期望 target 11，实际 %+v (%v)", req, err)
+	}
+
+	writeBuildFile(t, dir, PomFileName, "<project><artifactId>module</artifactId></project>")
+	if _, err := ReadPom(path); !errors.Is(err, ErrNoRequirement) {
+		t.Errorf("期望 ErrNoRequirement，实际 %v", err)
+	}
+}
+
+func TestReadGradleBuild(t *testing.T) {
+	tests := []struct {
+		script string
+		level  int
+		exact  bool
+	}{
+		{"java {\n    toolchain {\n        languageVersion = JavaLanguageVersion.of(21)\n    }\n}\n", 21, true},
+		{"java.toolchain.languageVersion.set(JavaLanguageVersion.of(\"17\"))\n", 17, true},
+		{"kotlin {\n    jvmToolchain(11)\n}\n", 11, true},
+		{"sourceCompatibility = JavaVersion.VERSION_1_8\ntargetCompatibility = '11'\n", 11, false},
+		{"java {\n    sourceCompatibility = JavaVersion.VERSION_17\n}\n", 17, false},
+		{"// languageVersion = JavaLanguageVersion.of(8)\nsourceCompatibility = 17\n", 17, false},
+	}
+	for _, tt := range tests {
+		path := writeBuildFile(t, t.TempDir(), GradleKotlinFileName, tt.script)
+		req, err := ReadGradleBuild(path)
+		if err != nil || req.Level != tt.level || req.Exact != tt.exact {
+			t.Errorf("%q: 期望 %d，实际 %+v (%v)", tt.script, tt.level, req, err)
+		}
+	}
+}
+
+func TestDetectRequirement(t *testing.T) {
+	root := t.TempDir()
+	module := filepath.Join(root, "service")
+	writeBuildFile(t, root, PomFileName, parentPom)
+	writeBuildFile(t, module, PomFileName, "<project><parent><artifactId>root</artifactId></parent></project>")
+
+	// 子模块未声明版本时使用父 pom
+	req, err := DetectRequirement(module)
+	if err != nil || req.Level != 17 || req.File != filepath.Join(root, PomFileName) {
+		t.Errorf("期望父 pom 的 17，实际 %+v (%v)", req, err)
+	}
+
+	path := writeBuildFile(t, module, GradleDaemonJVMFileName, "#This file is generated by updateDaemonJvm\ntoolchainVendor=ADOPTIUM\ntoolchainVersion=21\n")
+	req, err = DetectRequirement(module)
+	if err != nil || req.Level != 21 || req.Vendor != "ADOPTIUM" || req.String() != path+":3" {
+		t.Errorf("期望 gradle-daemon-jvm.properties 的 21，实际 %+v (%v)", req, err)
+	}
+
+	if _, err := DetectRequirement(t.TempDir()); !errors.Is(err, ErrNoRequirement) {
+		t.Errorf("期望 ErrNoRequirement，实际 %v", err)
+	}
+}
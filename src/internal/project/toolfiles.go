@@ -0,0 +1,104 @@
+package project
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Version files of other tools that are read as well, so projects set up for
+// asdf, SDKMAN! or jabba work without a .java-version file
+const (
+	ToolVersionsFileName = ".tool-versions"
+	SdkmanrcFileName     = ".sdkmanrc"
+	JvmrcFileName        = ".jvmrc"
+)
+
+// Pin formats; the identifiers of other tools need translating into selectors
+const (
+	FormatJenv   = "jenv"   // .java-version: JDK 名称或选择器
+	FormatAsdf   = "asdf"   // .tool-versions: java temurin-17.0.9+9
+	FormatSdkman = "sdkman" // .sdkmanrc: java=17.0.9-tem
+	FormatJabba  = "jabba"  // .jvmrc: zulu@1.17.0
+)
+
+// errNoJava means a version file exists but does not pin Java
+var errNoJava = errors.New("no java entry")
+
+// versionFiles in order of precedence within one directory
+var versionFiles = []struct {
+	name string
+	read func(string) (Pin, error)
+}{
+	{VersionFileName, ReadVersionFile},
+	{ToolVersionsFileName, ReadToolVersions},
+	{SdkmanrcFileName, ReadSdkmanrc},
+	{JvmrcFileName, ReadJvmrc},
+}
+
+// ReadToolVersions reads the java line of an asdf .tool-versions file. When several
+// versions are listed asdf uses the first one installed; the first is taken here.
+func ReadToolVersions(path string) (Pin, error) {
+	return scanVersionFile(path, func(text string) (string, bool) {
+		if i := strings.Index(text, "#"); i >= 0 {
+			text = text[:i]
+		}
+		fields := strings.Fields(text)
+		if len(fields) < 2 || fields[0] != "java" {
+			return "", false
+		}
+		return fields[1], true
+	}, FormatAsdf)
+}
+
+// ReadSdkmanrc reads the java property of a SDKMAN! .sdkmanrc file
+func ReadSdkmanrc(path string) (Pin, error) {
+	return scanVersionFile(path, func(text string) (string, bool) {
+		if strings.HasPrefix(text, "#") {
+			return "", false
+		}
+		key, value, ok := strings.Cut(text, "=")
+		if !ok || strings.TrimSpace(key) != "java" || strings.TrimSpace(value) == "" {
+			return "", false
+		}
+		return strings.TrimSpace(value), true
+	}, FormatSdkman)
+}
+
+// ReadJvmrc reads a jabba .jvmrc file, which holds a single version on its first line
+func ReadJvmrc(path string) (Pin, error) {
+	return scanVersionFile(path, func(text string) (string, bool) {
+		if strings.HasPrefix(text, "#") {
+			return "", false
+		}
+		return text, true
+	}, FormatJabba)
+}
+
+// scanVersionFile returns the first line for which match reports a Java identifier
+func scanVersionFile(path string, match func(string) (string, bool), format string) (Pin, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Pin{}, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if id, ok := match(text); ok {
+			return Pin{Selector: id, File: path, Line: line, Format: format}, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Pin{}, fmt.Errorf("%s: %v", path, err)
+	}
+	return Pin{}, errNoJava
+}
@@ -0,0 +1,108 @@
+// Package project finds the JDK a project directory asks for.
+package project
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// VersionFileName 是项目级 JDK 版本文件的名称
+const VersionFileName = ".java-version"
+
+// ErrNoPin is returned when no version file is found between a directory and the filesystem root
+var ErrNoPin = errors.New("no .java-version, .tool-versions, .sdkmanrc or .jvmrc file found")
+
+// Pin is a JDK selector requested by a file in a project directory
+type Pin struct {
+	Selector string // 名称或选择器表达式，如 17、temurin@21；其他工具的文件中为原始标识符
+	File     string // 声明该选择器的文件
+	Line     int    // 选择器所在行号，从 1 开始
+	Format   string // 文件格式，见 Format* 常量
+}
+
+func (p Pin) String() string {
+	return fmt.Sprintf("%s:%d", p.File, p.Line)
+}
+
+// FindPin walks up from dir to the filesystem root and returns the nearest pin.
+// Within a directory .java-version wins over .tool-versions, .sdkmanrc and .jvmrc;
+// files of other tools that do not mention Java are passed over.
+func FindPin(dir string) (Pin, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return Pin{}, err
+	}
+	for {
+		for _, vf := range versionFiles {
+			path := filepath.Join(dir, vf.name)
+			if info, err := os.Stat(path); err != nil || info.IsDir() {
+				continue
+			}
+			pin, err := vf.read(path)
+			if errors.Is(err, errNoJava) {
+				continue
+			}
+			return pin, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return Pin{}, ErrNoPin
+		}
+		dir = parent
+	}
+}
+
+// ReadVersionFile reads a .java-version file. The first non-empty line that is
+// not a # comment holds the selector, same as jenv-classic and jabba. A file
+// without one is treated like a missing file by FindPin.
+func ReadVersionFile(path string) (Pin, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Pin{}, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		return Pin{Selector: text, File: path, Line: line, Format: FormatJenv}, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return Pin{}, err
+	}
+	return Pin{}, fmt.Errorf("%s: file is empty: %w", path, errNoJava)
+}
+
+// WriteVersionFile writes selector to the .java-version file in dir and returns its path
+func WriteVersionFile(dir, selector string) (string, error) {
+	selector = strings.TrimSpace(selector)
+	if selector == "" || strings.ContainsAny(selector, "\r\n") {
+		return "", fmt.Errorf("invalid selector %q", selector)
+	}
+	path := filepath.Join(dir, VersionFileName)
+	if err := os.WriteFile(path, []byte(selector+"\n"), 0644); err != nil {
+		return "", fmt.Errorf("写入 %s 失败: %v", path, err)
+	}
+	return path, nil
+}
+
+// RemoveVersionFile deletes the .java-version file in dir, if any
+func RemoveVersionFile(dir string) (bool, error) {
+	path := filepath.Join(dir, VersionFileName)
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,126 @@
+package project
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindPinWalksUp(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "module", "src", "main")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("创建目录失败: %v", err)
+	}
+	content := "# 项目 JDK\n\n  temurin@21  \n"
+	if err := os.WriteFile(filepath.Join(root, VersionFileName), []byte(content), 0644); err != nil {
+		t.Fatalf("写入文件失败: %v", err)
+	}
+
+	pin, err := FindPin(nested)
+	if err != nil {
+		t.Fatalf("查找失败: %v", err)
+	}
+	if pin.Selector != "temurin@21" || pin.Line != 3 {
+		t.Errorf("解析结果错误: %+v", pin)
+	}
+	if pin.File != filepath.Join(root, VersionFileName) {
+		t.Errorf("文件路径错误: %s", pin.File)
+	}
+
+	// 更近的文件优先
+	path, err := WriteVersionFile(filepath.Join(root, "module"), "17")
+	if err != nil {
+		t.Fatalf("写入失败: %v", err)
+	}
+	if pin, _ := FindPin(nested); pin.Selector != "17" || pin.File != path {
+		t.Errorf("期望使用最近的文件，实际 %+v", pin)
+	}
+
+	if removed, err := RemoveVersionFile(filepath.Join(root, "module")); err != nil || !removed {
+		t.Errorf("删除失败: %v", err)
+	}
+	if removed, _ := RemoveVersionFile(filepath.Join(root, "module")); removed {
+		t.Error("文件不存在时不应报告删除")
+	}
+}
+
+func TestFindPinNone(t *testing.T) {
+	if _, err := FindPin(t.TempDir()); !errors.Is(err, ErrNoPin) {
+		t.Errorf("期望 ErrNoPin，实际 %v", err)
+	}
+}
+
+func TestFindPinSkipsEmptyVersionFile(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "app")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("创建目录失败: %v", err)
+	}
+	// 空的 .java-version 视为不存在，继续查找同目录的其他文件和上级目录
+	if err := os.WriteFile(filepath.Join(nested, VersionFileName), []byte("  \n# 注释\n"), 0644); err != nil {
+		t.Fatalf("写入文件失败: %v", err)
+	}
+	if _, err := FindPin(nested); !errors.Is(err, ErrNoPin) {
+		t.Errorf("期望 ErrNoPin，实际 %v", err)
+	}
+
+	if _, err := WriteVersionFile(root, "21"); err != nil {
+		t.Fatalf("写入失败: %v", err)
+	}
+	if pin, err := FindPin(nested); err != nil || pin.Selector != "21" {
+		t.Errorf("期望使用上级目录的文件，实际 %+v (%v)", pin, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(nested, SdkmanrcFileName), []byte("java=17.0.9-tem\n"), 0644); err != nil {
+		t.Fatalf("写入文件失败: %v", err)
+	}
+	if pin, err := FindPin(nested); err != nil || pin.Format != FormatSdkman {
+		t.Errorf("期望使用同目录的 .sdkmanrc，实际 %+v (%v)", pin, err)
+	}
+}
+
+func TestWriteVersionFileRejectsNewlines(t *testing.T) {
+	for _, sel := range []string{"", "  ", "17\n21"} {
+		if _, err := WriteVersionFile(t.TempDir(), sel); err == nil {
+			t.Errorf("期望 %q 写入失败", sel)
+		}
+	}
+}
+
+func TestFindPinToolFiles(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "app")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("创建目录失败: %v", err)
+	}
+	write := func(dir, name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("写入文件失败: %v", err)
+		}
+	}
+
+	write(root, JvmrcFileName, "zulu@1.17.0\n")
+	// 不含 java 的 .tool-versions 会被跳过
+	write(nested, ToolVersionsFileName, "nodejs 20.10.0 # 前端\n")
+	pin, err := FindPin(nested)
+	if err != nil || pin.Format != FormatJabba || pin.Selector != "zulu@1.17.0" || pin.Line != 1 {
+		t.Errorf("期望使用上级的 .jvmrc，实际 %+v (%v)", pin, err)
+	}
+
+	write(nested, SdkmanrcFileName, "# Enable auto-env\njava = 17.0.9-tem\n")
+	if pin, _ := FindPin(nested); pin.Format != FormatSdkman || pin.Selector != "17.0.9-tem" || pin.Line != 2 {
+		t.Errorf("期望使用 .sdkmanrc，实际 %+v", pin)
+	}
+
+	write(nested, ToolVersionsFileName, "nodejs 20.10.0\njava temurin-17.0.9+9 # LTS\n")
+	if pin, _ := FindPin(nested); pin.Format != FormatAsdf || pin.Selector != "temurin-17.0.9+9" || pin.Line != 2 {
+		t.Errorf("期望 .tool-versions 优先于 .sdkmanrc，实际 %+v", pin)
+	}
+
+	write(nested, VersionFileName, "17\n")
+	if pin, _ := FindPin(nested); pin.Format != FormatJenv {
+		t.Errorf("期望 .java-version 优先，实际 %+v", pin)
+	}
+}
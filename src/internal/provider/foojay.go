@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/java"
+)
+
+// DefaultFoojayURL is the public Foojay Disco API
+const DefaultFoojayURL = "https://api.foojay.io/disco/v3.0"
+
+// foojayDistributions maps jenv distributions onto Disco API distribution names
+var foojayDistributions = map[string]string{
+	java.VendorTemurin:   "temurin",
+	java.VendorZulu:      "zulu",
+	java.VendorCorretto:  "corretto",
+	java.VendorLiberica:  "liberica",
+	java.VendorGraalVM:   "graalvm_community",
+	java.VendorSemeru:    "semeru",
+	java.VendorOracle:    "oracle",
+	java.VendorMicrosoft: "microsoft",
+	java.VendorSAP:       "sap_machine",
+	java.VendorJBR:       "jetbrains",
+	java.VendorOpenJDK:   "oracle_open_jdk",
+}
+
+// foojayOS and foojayArch translate GOOS and GOARCH into Disco API names
+var (
+	foojayOS   = map[string]string{"linux": "linux", "darwin": "macos", "windows": "windows"}
+	foojayArch = map[string]string{"amd64": "x64", "arm64": "aarch64", "386": "x86", "arm": "arm", "ppc64le": "ppc64le", "s390x": "s390x", "riscv64": "riscv64"}
+)
+
+// Foojay lists JDKs through the Foojay Disco API
+type Foojay struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewFoojay returns a Disco API client; an empty baseURL uses DefaultFoojayURL
+func NewFoojay(baseURL string, client *http.Client) *Foojay {
+	if baseURL == "" {
+		baseURL = DefaultFoojayURL
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Foojay{BaseURL: strings.TrimRight(baseURL, "/"), Client: client}
+}
+
+func (f *Foojay) Name() string { return NameFoojay }
+
+type foojayPackage struct {
+	ArchiveType     string `json:"archive_type"`
+	Distribution    string `json:"distribution"`
+	JavaVersion     string `json:"java_version"`
+	OperatingSystem string `json:"operating_system"`
+	Architecture    string `json:"architecture"`
+	PackageType     string `json:"package_type"`
+	TermOfSupport   string `json:"term_of_support"`
+	Filename        string `json:"filename"`
+	Links           struct {
+		PkgInfoURI string `json:"pkg_info_uri"`
+	} `json:"links"`
+}
+
+type foojayInfo struct {
+	Filename          string `json:"filename"`
+	DirectDownloadURI string `json:"direct_download_uri"`
+	Checksum          string `json:"checksum"`
+	ChecksumType      string `json:"checksum_type"`
+}
+
+// List queries /packages for JDK archives of the query's distribution and version
+func (f *Foojay) List(ctx context.Context, q Query) ([]Package, error) {
+	params := url.Values{}
+	params.Set("package_type", "jdk")
+	params.Set("release_status", "ga")
+	params.Add("archive_type", "tar.gz")
+	params.Add("archive_type", "zip")
+	if q.Vendor != "" {
+		dist, ok := foojayDistributions[q.Vendor]
+		if !ok {
+			dist = q.Vendor
+		}
+		params.Set("distribution", dist)
+	}
+	if q.Feature > 0 {
+		params.Set("jdk_version", strconv.Itoa(q.Feature))
+	}
+	if name, ok := foojayOS[q.OS]; ok {
+		params.Set("operating_system", name)
+	}
+	if arch, ok := foojayArch[q.Arch]; ok {
+		params.Set("architecture", arch)
+	}
+	if q.OS == "linux" {
+		params.Set("libc_type", "glibc")
+	}
+
+	var resp struct {
+		Result []foojayPackage `json:"result"`
+	}
+	if err := f.get(ctx, f.BaseURL+"/packages?"+params.Encode(), &resp); err != nil {
+		return nil, err
+	}
+
+	var packages []Package
+	for _, fp := range resp.Result {
+		if fp.PackageType != "" && fp.PackageType != "jdk" {
+			continue
+		}
+		packages = append(packages, Package{
+			Vendor:      foojayVendor(fp.Distribution),
+			Version:     fp.JavaVersion,
+			OS:          reverseLookup(foojayOS, fp.OperatingSystem),
+			Arch:        reverseLookup(foojayArch, fp.Architecture),
+			ArchiveType: fp.ArchiveType,
+			Filename:    fp.Filename,
+			LTS:         fp.TermOfSupport == "lts",
+			infoURL:     fp.Links.PkgInfoURI,
+		})
+	}
+	return packages, nil
+}
+
+// Resolve fetches the package info for the direct download URL and checksum
+func (f *Foojay) Resolve(ctx context.Context, p Package) (Package, error) {
+	if p.URL != "" || p.infoURL == "" {
+		return p, nil
+	}
+	var resp struct {
+		Result []foojayInfo `json:"result"`
+	}
+	if err := f.get(ctx, p.infoURL, &resp); err != nil {
+		return p, err
+	}
+	if len(resp.Result) == 0 || resp.Result[0].DirectDownloadURI == "" {
+		return p, fmt.Errorf("foojay has no download link for %s", p.Filename)
+	}
+	info := resp.Result[0]
+	p.URL = info.DirectDownloadURI
+	if strings.EqualFold(info.ChecksumType, "sha256") {
+		p.Checksum = strings.ToLower(info.Checksum)
+	}
+	if info.Filename != "" {
+		p.Filename = info.Filename
+	}
+	return p, nil
+}
+
+func (f *Foojay) get(ctx context.Context, rawURL string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("foojay: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("foojay: %s returned %s", rawURL, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("foojay: invalid response from %s: %v", rawURL, err)
+	}
+	return nil
+}
+
+// foojayVendor maps a Disco API distribution back onto a jenv distribution
+func foojayVendor(dist string) string {
+	if vendor := reverseLookup(foojayDistributions, dist); vendor != "" {
+		return vendor
+	}
+	return java.NormalizeVendor(strings.ReplaceAll(dist, "_", ""))
+}
+
+func reverseLookup(m map[string]string, value string) string {
+	for k, v := range m {
+		if v == value {
+			return k
+		}
+	}
+	return ""
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CatalogFile is the catalog a mirror serves at its root
+const CatalogFile = "catalog.json"
+
+// Mirror lists JDKs from a JSON catalog in a local directory or on an HTTP server,
+// for machines that cannot reach the internet. The catalog looks like
+//
+//	{"packages": [{"vendor": "temurin", "version": "17.0.9+9", "os": "linux",
+//	  "arch": "amd64", "url": "temurin/OpenJDK17U-jdk_x64_linux_hotspot_17.0.9_9.tar.gz",
+//	  "sha256": "..."}]}
+//
+// Relative URLs are resolved against the catalog's location.
+type Mirror struct {
+	Location string // 目录、catalog.json 路径或 HTTP 地址
+	Client   *http.Client
+}
+
+// NewMirror returns a provider for the catalog at location
+func NewMirror(location string, client *http.Client) *Mirror {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Mirror{Location: location, Client: client}
+}
+
+func (m *Mirror) Name() string { return NameMirror }
+
+type mirrorCatalog struct {
+	Packages []struct {
+		Vendor   string `json:"vendor"`
+		Version  string `json:"version"`
+		OS       string `json:"os"`
+		Arch     string `json:"arch"`
+		URL      string `json:"url"`
+		SHA256   string `json:"sha256"`
+		Filename string `json:"filename"`
+		LTS      bool   `json:"lts"`
+	} `json:"packages"`
+}
+
+// List reads the catalog and returns the packages for the query's platform
+func (m *Mirror) List(ctx context.Context, q Query) ([]Package, error) {
+	catalogURL := m.catalogURL()
+	data, err := m.read(ctx, catalogURL)
+	if err != nil {
+		return nil, err
+	}
+	var catalog mirrorCatalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("mirror: invalid %s: %v", catalogURL, err)
+	}
+
+	var packages []Package
+	for _, e := range catalog.Packages {
+		if (q.OS != "" && e.OS != q.OS) || (q.Arch != "" && e.Arch != q.Arch) {
+			continue
+		}
+		if q.Vendor != "" && e.Vendor != q.Vendor {
+			continue
+		}
+		filename := e.Filename
+		if filename == "" {
+			filename = baseName(e.URL)
+		}
+		packages = append(packages, Package{
+			Vendor:      e.Vendor,
+			Version:     e.Version,
+			OS:          e.OS,
+			Arch:        e.Arch,
+			ArchiveType: archiveType(filename),
+			Filename:    filename,
+			URL:         resolveURL(catalogURL, e.URL),
+			Checksum:    strings.ToLower(e.SHA256),
+			LTS:         e.LTS,
+		})
+	}
+	return packages, nil
+}
+
+// Resolve returns p unchanged, mirror catalogs carry the download URL
+func (m *Mirror) Resolve(ctx context.Context, p Package) (Package, error) {
+	return p, nil
+}
+
+// catalogURL points at catalog.json: directories and base URLs get it appended
+func (m *Mirror) catalogURL() string {
+	loc := m.Location
+	if strings.HasSuffix(loc, ".json") {
+		return loc
+	}
+	if isHTTP(loc) {
+		return strings.TrimRight(loc, "/") + "/" + CatalogFile
+	}
+	return filepath.Join(loc, CatalogFile)
+}
+
+func (m *Mirror) read(ctx context.Context, location string) ([]byte, error) {
+	if !isHTTP(location) {
+		return os.ReadFile(location)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("mirror: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("mirror: %s returned %s", location, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// resolveURL resolves a package URL relative to the catalog
+func resolveURL(catalog, ref string) string {
+	if isHTTP(ref) || filepath.IsAbs(ref) {
+		return ref
+	}
+	if isHTTP(catalog) {
+		base, err := url.Parse(catalog)
+		if err != nil {
+			return ref
+		}
+		r, err := url.Parse(ref)
+		if err != nil {
+			return ref
+		}
+		return base.ResolveReference(r).String()
+	}
+	return filepath.Join(filepath.Dir(catalog), filepath.FromSlash(ref))
+}
+
+func isHTTP(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+// baseName returns the last element of a URL or file path
+func baseName(ref string) string {
+	ref = strings.ReplaceAll(ref, `\`, "/")
+	if i := strings.IndexAny(ref, "?#"); i >= 0 {
+		ref = ref[:i]
+	}
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+func archiveType(filename string) string {
+	switch lower := strings.ToLower(filename); {
+	case strings.HasSuffix(lower, ".tar.gz"):
+		return "tar.gz"
+	case strings.HasSuffix(lower, ".tgz"):
+		return "tgz"
+	case strings.HasSuffix(lower, ".zip"):
+		return "zip"
+	}
+	return ""
+}
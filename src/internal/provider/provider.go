@@ -0,0 +1,174 @@
+// Package provider lists and locates JDK packages available for download.
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/whywhathow/jenv/internal/config"
+	"github.com/whywhathow/jenv/internal/java"
+)
+
+// Provider names accepted in config.json and by --provider
+const (
+	NameFoojay = "foojay"
+	NameMirror = "mirror"
+)
+
+// DefaultVendor is installed when a selector names no distribution
+const DefaultVendor = java.VendorTemurin
+
+// ErrNoPackage is returned when no package matches a selector
+var ErrNoPackage = errors.New("no matching JDK package")
+
+// Package is a downloadable JDK archive
+type Package struct {
+	Vendor      string // jenv 的发行版名称，如 temurin
+	Version     string // Java 版本，如 17.0.9+9
+	OS          string // GOOS 命名
+	Arch        string // GOARCH 命名
+	ArchiveType string // tar.gz 或 zip
+	Filename    string
+	URL         string // 下载地址；为空时需先调用 Provider.Resolve
+	Checksum    string // 十六进制 SHA-256，未知时为空
+	LTS         bool
+	infoURL     string
+}
+
+// Query narrows down the packages a provider lists
+type Query struct {
+	Vendor  string // 发行版，空值表示全部
+	Feature int    // 特性版本，0 表示全部
+	OS      string // GOOS 命名
+	Arch    string // GOARCH 命名
+}
+
+// Provider is a catalog of downloadable JDKs
+type Provider interface {
+	Name() string
+	// List returns the packages for the query, in no particular order
+	List(ctx context.Context, q Query) ([]Package, error)
+	// Resolve fills in the download URL and checksum of a listed package
+	Resolve(ctx context.Context, p Package) (Package, error)
+}
+
+// New returns the provider configured in settings; a non-empty name overrides it
+func New(settings config.InstallSettings, name string) (Provider, error) {
+	if name == "" {
+		name = settings.Provider
+	}
+	client, err := HTTPClient(settings.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	switch name {
+	case "", NameFoojay:
+		return NewFoojay(settings.FoojayURL, client), nil
+	case NameMirror:
+		if settings.MirrorURL == "" {
+			return nil, errors.New("no mirror configured: set install.mirror_url in config.json or pass --mirror")
+		}
+		return NewMirror(settings.MirrorURL, client), nil
+	}
+	return nil, fmt.Errorf("unknown provider %q, expected foojay or mirror", name)
+}
+
+// HTTPClient returns the client used for catalogs and downloads. Without an
+// explicit proxy the usual HTTPS_PROXY, HTTP_PROXY and NO_PROXY variables apply.
+func HTTPClient(proxy string) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxy != "" {
+		u, err := url.Parse(proxy)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy %q", proxy)
+		}
+		transport.Proxy = http.ProxyURL(u)
+	}
+	// 仅限制建立连接和等待响应头的时间，下载大文件不设总超时
+	transport.ResponseHeaderTimeout = 30 * time.Second
+	return &http.Client{Transport: transport}, nil
+}
+
+// QueryFor builds the query for a selector on this machine
+func QueryFor(sel java.Selector) Query {
+	q := Query{Vendor: sel.Vendor, OS: runtime.GOOS, Arch: runtime.GOARCH}
+	for _, c := range sel.Constraints {
+		if c.Op == "" || c.Op == "=" {
+			q.Feature = c.Version.Feature()
+		}
+	}
+	return q
+}
+
+// Matches reports whether a package satisfies the selector
+func Matches(sel java.Selector, p Package) bool {
+	return sel.Matches(config.JDK{JavaRuntimeVersion: p.Version, Vendor: p.Vendor})
+}
+
+// Sort orders packages newest first, GA builds before early access builds
+func Sort(packages []Package) {
+	sort.SliceStable(packages, func(i, j int) bool {
+		vi, _ := java.ParseVersion(packages[i].Version)
+		vj, _ := java.ParseVersion(packages[j].Version)
+		if vi.IsEA() != vj.IsEA() {
+			return !vi.IsEA()
+		}
+		if c := vi.Compare(vj); c != 0 {
+			return c > 0
+		}
+		if packages[i].Vendor != packages[j].Vendor {
+			return packages[i].Vendor < packages[j].Vendor
+		}
+		// 同一版本优先 tar.gz，其次 zip
+		return archiveRank(packages[i].ArchiveType) < archiveRank(packages[j].ArchiveType)
+	})
+}
+
+// Find returns the best package for a selector expression such as temurin@21 or 17.
+// Without a distribution, DefaultVendor is used.
+func Find(ctx context.Context, p Provider, expr string) (Package, error) {
+	sel, err := java.ParseSelector(expr)
+	if err != nil {
+		return Package{}, err
+	}
+	if sel.Vendor == "" {
+		sel.Vendor = DefaultVendor
+	}
+	packages, err := p.List(ctx, QueryFor(sel))
+	if err != nil {
+		return Package{}, err
+	}
+	var matching []Package
+	for _, pkg := range packages {
+		if Matches(sel, pkg) && supportedArchive(pkg.ArchiveType) {
+			matching = append(matching, pkg)
+		}
+	}
+	if len(matching) == 0 {
+		return Package{}, fmt.Errorf("%w: %s for %s/%s in %s", ErrNoPackage, expr, runtime.GOOS, runtime.GOARCH, p.Name())
+	}
+	Sort(matching)
+	return p.Resolve(ctx, matching[0])
+}
+
+func supportedArchive(archiveType string) bool {
+	return archiveRank(archiveType) < 2
+}
+
+// archiveRank orders archive types by preference: tar.gz keeps permissions best
+func archiveRank(archiveType string) int {
+	switch strings.ToLower(archiveType) {
+	case "tar.gz", "tgz":
+		return 0
+	case "zip":
+		return 1
+	}
+	return 2
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// foojayStandIn serves the two Disco API endpoints jenv uses
+func foojayStandIn(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/disco/v3.0/packages":
+			q := r.URL.Query()
+			if q.Get("distribution") != "temurin" || q.Get("jdk_version") != "21" || q.Get("package_type") != "jdk" {
+				t.Errorf("查询参数错误: %s", r.URL.RawQuery)
+			}
+			pkg := func(id, version, archive string) string {
+				return fmt.Sprintf(`{"id":%q,"archive_type":%q,"distribution":"temurin","java_version":%q,
+					"operating_system":"linux","architecture":"x64","package_type":"jdk","term_of_support":"lts",
+					"filename":"OpenJDK21U-%s.%s","links":{"pkg_info_uri":"%s/disco/v3.0/ids/%s"}}`,
+					id, archive, version, id, archive, server.URL, id)
+			}
+			fmt.Fprintf(w, `{"result":[%s,%s,%s],"message":""}`,
+				pkg("a", "21.0.1+12", "tar.gz"), pkg("b", "21.0.2+13", "tar.gz"), pkg("c", "21.0.2+13", "zip"))
+		case strings.HasPrefix(r.URL.Path, "/disco/v3.0/ids/"):
+			id := strings.TrimPrefix(r.URL.Path, "/disco/v3.0/ids/")
+			fmt.Fprintf(w, `{"result":[{"filename":"jdk-%s.tar.gz","direct_download_uri":"https://example.com/%s.tar.gz","checksum":"ABC123","checksum_type":"sha256"}]}`, id, id)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFoojayFind(t *testing.T) {
+	server := foojayStandIn(t)
+	p := NewFoojay(server.URL+"/disco/v3.0/", server.Client())
+
+	pkg, err := Find(context.Background(), p, "temurin@21")
+	if err != nil {
+		t.Fatalf("查找失败: %v", err)
+	}
+	if pkg.Version != "21.0.2+13" || pkg.ArchiveType != "tar.gz" || pkg.Vendor != "temurin" || !pkg.LTS {
+		t.Errorf("应选择最新的 tar.gz，实际 %+v", pkg)
+	}
+	if pkg.URL != "https://example.com/b.tar.gz" || pkg.Checksum != "abc123" {
+		t.Errorf("下载信息错误: %+v", pkg)
+	}
+
+	if _, err := Find(context.Background(), p, "temurin@21.0.9"); !errors.Is(err, ErrNoPackage) {
+		t.Errorf("期望 ErrNoPackage，实际 %v", err)
+	}
+}
+
+func TestFoojayErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewFoojay(server.URL, server.Client()).List(context.Background(), Query{Feature: 17})
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("期望包含状态码的错误，实际 %v", err)
+	}
+}
+
+const testCatalog = `{"packages": [
+  {"vendor": "temurin", "version": "17.0.9+9", "os": "linux", "arch": "amd64", "url": "temurin/jdk-17.0.9.tar.gz", "sha256": "AA"},
+  {"vendor": "temurin", "version": "17.0.8+7", "os": "linux", "arch": "amd64", "url": "temurin/jdk-17.0.8.tar.gz"},
+  {"vendor": "zulu", "version": "17.0.9+8", "os": "linux", "arch": "amd64", "url": "https://cdn.example.com/zulu17.zip"},
+  {"vendor": "temurin", "version": "17.0.9+9", "os": "windows", "arch": "amd64", "url": "temurin/jdk-17.0.9.zip"}
+]}`
+
+func TestMirror(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, CatalogFile), []byte(testCatalog), 0644); err != nil {
+		t.Fatalf("写入目录失败: %v", err)
+	}
+	server := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer server.Close()
+
+	q := Query{Vendor: "temurin", OS: "linux", Arch: "amd64"}
+	for _, m := range []*Mirror{NewMirror(dir, nil), NewMirror(server.URL+"/", server.Client())} {
+		packages, err := m.List(context.Background(), q)
+		if err != nil {
+			t.Fatalf("%s: 读取目录失败: %v", m.Location, err)
+		}
+		if len(packages) != 2 {
+			t.Fatalf("%s: 期望 2 个包，实际 %+v", m.Location, packages)
+		}
+		Sort(packages)
+		pkg := packages[0]
+		if pkg.Version != "17.0.9+9" || pkg.Checksum != "aa" || pkg.ArchiveType != "tar.gz" || pkg.Filename != "jdk-17.0.9.tar.gz" {
+			t.Errorf("%s: 包信息错误: %+v", m.Location, pkg)
+		}
+		want := filepath.Join(dir, "temurin", "jdk-17.0.9.tar.gz")
+		if strings.HasPrefix(m.Location, "http") {
+			want = server.URL + "/temurin/jdk-17.0.9.tar.gz"
+		}
+		if pkg.URL != want {
+			t.Errorf("%s: 相对地址应基于目录位置解析，期望 %s，实际 %s", m.Location, want, pkg.URL)
+		}
+	}
+
+	packages, _ := NewMirror(dir, nil).List(context.Background(), Query{Vendor: "zulu", OS: "linux", Arch: "amd64"})
+	if len(packages) != 1 || packages[0].URL != "https://cdn.example.com/zulu17.zip" {
+		t.Errorf("绝对地址应保持不变: %+v", packages)
+	}
+}
@@ -0,0 +1,110 @@
+package shell
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Variables the cd hook uses to cache its last answer in the live shell
+const (
+	// HookDirKey is the directory the hook last resolved; the hook does nothing until $PWD differs
+	HookDirKey = "JENV_HOOK_DIR"
+	// HookJDKKey is the Java home the hook last applied
+	HookJDKKey = "JENV_HOOK_JDK"
+)
+
+// HookScript returns the code that installs the cd hook in the shell. self is the
+// jenv executable the hook calls when the directory changes. Loading the script
+// clears the cache so a new shell always resolves its first directory.
+func HookScript(c ShellConfig, self string) (string, error) {
+	switch c.Type {
+	case Bash:
+		return fmt.Sprintf(`%s
+_jenv_hook() {
+  local ret=$?
+  if [ "$PWD" != "${%s-}" ]; then
+    eval "$(%s hook-env --shell bash)"
+  fi
+  return $ret
+}
+if [[ ";${PROMPT_COMMAND[*]:-};" != *";_jenv_hook;"* ]]; then
+  PROMPT_COMMAND="_jenv_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi
+`, c.UnsetCommand(HookDirKey), HookDirKey, posixQuote(self)), nil
+	case Zsh:
+		return fmt.Sprintf(`%s
+_jenv_hook() {
+  if [[ "$PWD" != "${%s-}" ]]; then
+    eval "$(%s hook-env --shell zsh)"
+  fi
+}
+typeset -ag chpwd_functions
+if (( ! ${chpwd_functions[(I)_jenv_hook]} )); then
+  chpwd_functions=(_jenv_hook $chpwd_functions)
+fi
+_jenv_hook
+`, c.UnsetCommand(HookDirKey), HookDirKey, posixQuote(self)), nil
+	case Fish:
+		return fmt.Sprintf(`%s
+function _jenv_hook --on-variable PWD
+    if test "$PWD" != "$%s"
+        %s hook-env --shell fish | source
+    end
+end
+_jenv_hook
+`, c.UnsetCommand(HookDirKey), HookDirKey, fishQuote(self)), nil
+	}
+	return "", fmt.Errorf("cd hooks are not supported for %s (use bash, zsh or fish)", c.Type)
+}
+
+// HookUpdate returns the code printed when the hook fires in dir. When home is the
+// Java home the shell already uses, only the directory cache is refreshed.
+func HookUpdate(c ShellConfig, dir, home string, vars []EnvVar, lookup func(string) (string, bool)) string {
+	lines := []string{c.SetCommand(HookDirKey, dir)}
+	if last, ok := lookup(HookJDKKey); !ok || last != home {
+		for _, v := range vars {
+			lines = append(lines, c.SetCommand(v.Key, v.Value))
+		}
+		lines = append(lines, c.SetCommand(HookJDKKey, home))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// InstallHook appends line to the shell's configuration file unless it is already there.
+// It returns the file path and whether the file was changed.
+func InstallHook(shellType ShellType, line string) (string, bool, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get user home directory: %v", err)
+	}
+	config, exists := GetShellConfigs()[shellType]
+	if !exists {
+		return "", false, fmt.Errorf("unsupported shell type: %s", shellType)
+	}
+	configPath := filepath.Join(homeDir, config.ConfigFile)
+
+	content, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return configPath, false, fmt.Errorf("failed to read config file %s: %v", configPath, err)
+	}
+	for _, existing := range strings.Split(string(content), "\n") {
+		if strings.TrimSpace(existing) == line {
+			return configPath, false, nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return configPath, false, fmt.Errorf("failed to create config directory: %v", err)
+	}
+	text := string(content)
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	text += fmt.Sprintf("\n%s Added by jenv\n%s\n", config.CommentChar, line)
+	if err := os.WriteFile(configPath, []byte(text), 0644); err != nil {
+		return configPath, false, fmt.Errorf("failed to write config file %s: %v", configPath, err)
+	}
+	return configPath, true, nil
+}
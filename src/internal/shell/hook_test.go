@@ -0,0 +1,83 @@
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHookScript(t *testing.T) {
+	for name, want := range map[string]string{
+		"bash": "PROMPT_COMMAND=",
+		"zsh":  "chpwd_functions=",
+		"fish": "--on-variable PWD",
+	} {
+		sh, _ := GetSessionShellConfig(name)
+		script, err := HookScript(sh, "/usr/local/bin/jenv")
+		if err != nil {
+			t.Fatalf("%s: 生成 hook 失败: %v", name, err)
+		}
+		if !strings.Contains(script, want) || !strings.Contains(script, "hook-env --shell "+name) {
+			t.Errorf("%s: hook 内容错误:\n%s", name, script)
+		}
+	}
+
+	sh, _ := GetSessionShellConfig("pwsh")
+	if _, err := HookScript(sh, "jenv"); err == nil {
+		t.Error("期望 pwsh 不支持 hook")
+	}
+}
+
+func TestHookUpdateCache(t *testing.T) {
+	sh, _ := GetSessionShellConfig("bash")
+	vars := []EnvVar{{Key: "JAVA_HOME", Value: "/opt/jdk17"}, {Key: "PATH", Value: "/opt/jdk17/bin:/usr/bin"}}
+
+	env := map[string]string{}
+	lookup := func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+
+	update := HookUpdate(sh, "/src/app", "/opt/jdk17", vars, lookup)
+	for _, line := range []string{
+		"export JENV_HOOK_DIR='/src/app'",
+		"export JAVA_HOME='/opt/jdk17'",
+		"export JENV_HOOK_JDK='/opt/jdk17'",
+	} {
+		if !strings.Contains(update, line) {
+			t.Errorf("缺少 %q:\n%s", line, update)
+		}
+	}
+
+	// JDK 未变化时只更新目录缓存
+	env[HookJDKKey] = "/opt/jdk17"
+	update = HookUpdate(sh, "/src/app/sub", "/opt/jdk17", vars, lookup)
+	if update != "export JENV_HOOK_DIR='/src/app/sub'\n" {
+		t.Errorf("JDK 未变化时不应修改环境:\n%s", update)
+	}
+}
+
+func TestInstallHook(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	bashrc := filepath.Join(home, ".bashrc")
+	if err := os.WriteFile(bashrc, []byte("alias ll='ls -l'"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	line := `eval "$(jenv hook bash)"`
+	path, added, err := InstallHook(Bash, line)
+	if err != nil || !added || path != bashrc {
+		t.Fatalf("安装失败: %s %v %v", path, added, err)
+	}
+	if _, added, _ := InstallHook(Bash, line); added {
+		t.Error("重复安装不应修改文件")
+	}
+
+	content, _ := os.ReadFile(bashrc)
+	if !strings.HasPrefix(string(content), "alias ll='ls -l'\n") || strings.Count(string(content), line) != 1 {
+		t.Errorf("配置文件内容错误:\n%s", content)
+	}
+}
@@ -0,0 +1,153 @@
+package shell
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Variables used to remember what 'jenv shell' replaced, so '--unset' can put it back
+const (
+	// SavedVarsKey lists the variables saved by the first 'jenv shell' in a session
+	SavedVarsKey = "JENV_SHELL_SAVED"
+	// SavedVarPrefix prefixes the saved value of each variable; it is absent when the variable was unset
+	SavedVarPrefix = "JENV_SHELL_OLD_"
+)
+
+// EnvVar is an environment variable to set in the calling shell
+type EnvVar struct {
+	Key   string
+	Value string
+}
+
+// GetSessionShellConfig returns the configuration used to generate session code for
+// the named shell. Besides the shells of GetShellConfigs it accepts sh and pwsh.
+func GetSessionShellConfig(name string) (ShellConfig, error) {
+	switch strings.ToLower(name) {
+	case "sh", "posix":
+		name = string(Profile)
+	case "pwsh", "powershell":
+		return ShellConfig{
+			Type:        PowerShell,
+			ConfigFile:  powerShellProfile(),
+			ExportCmd:   "$env:",
+			SourceCmd:   ".",
+			CommentChar: "#",
+		}, nil
+	}
+	config, ok := GetShellConfigs()[ShellType(strings.ToLower(name))]
+	if !ok {
+		return ShellConfig{}, fmt.Errorf("unsupported shell: %s (use bash, zsh, fish, sh or pwsh)", name)
+	}
+	return config, nil
+}
+
+// DetectSessionShell guesses the shell that will evaluate jenv's output
+func DetectSessionShell() ShellType {
+	if runtime.GOOS == "windows" {
+		return PowerShell
+	}
+	return GetCurrentShell()
+}
+
+func powerShellProfile() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join("Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1")
+	}
+	return filepath.Join(".config", "powershell", "Microsoft.PowerShell_profile.ps1")
+}
+
+// SetCommand returns a command that sets key to the literal value in the current session
+func (c ShellConfig) SetCommand(key, value string) string {
+	switch c.Type {
+	case Fish:
+		if strings.HasSuffix(key, "PATH") {
+			// fish 把 PATH 类变量作为列表处理
+			return c.setPathFish(key, value)
+		}
+		return fmt.Sprintf("%s %s %s", c.ExportCmd, key, fishQuote(value))
+	case PowerShell:
+		return fmt.Sprintf("%s%s = %s", c.ExportCmd, key, powerShellQuote(value))
+	default:
+		return fmt.Sprintf("%s %s=%s", c.ExportCmd, key, posixQuote(value))
+	}
+}
+
+func (c ShellConfig) setPathFish(key, value string) string {
+	parts := []string{c.ExportCmd, key}
+	for _, entry := range filepath.SplitList(value) {
+		if entry != "" {
+			parts = append(parts, fishQuote(entry))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// UnsetCommand returns a command that removes key from the current session
+func (c ShellConfig) UnsetCommand(key string) string {
+	switch c.Type {
+	case Fish:
+		return fmt.Sprintf("set -e %s", key)
+	case PowerShell:
+		return fmt.Sprintf("Remove-Item Env:%s -ErrorAction SilentlyContinue", key)
+	default:
+		return fmt.Sprintf("unset %s", key)
+	}
+}
+
+// ActivateScript returns code that sets vars in the calling shell. The first call in a
+// session saves the previous values of vars, so DeactivateScript can restore them.
+func ActivateScript(c ShellConfig, vars []EnvVar, lookup func(string) (string, bool)) string {
+	var lines []string
+	if _, saved := lookup(SavedVarsKey); !saved {
+		keys := make([]string, len(vars))
+		for i, v := range vars {
+			keys[i] = v.Key
+			if old, ok := lookup(v.Key); ok {
+				lines = append(lines, c.SetCommand(SavedVarPrefix+v.Key, old))
+			}
+		}
+		lines = append(lines, c.SetCommand(SavedVarsKey, strings.Join(keys, " ")))
+	}
+	for _, v := range vars {
+		lines = append(lines, c.SetCommand(v.Key, v.Value))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// DeactivateScript returns code that restores the variables saved by ActivateScript.
+// It reports false when no 'jenv shell' session is active.
+func DeactivateScript(c ShellConfig, lookup func(string) (string, bool)) (string, bool) {
+	saved, ok := lookup(SavedVarsKey)
+	if !ok {
+		return "", false
+	}
+	var lines []string
+	for _, key := range strings.Fields(saved) {
+		if old, ok := lookup(SavedVarPrefix + key); ok {
+			lines = append(lines, c.SetCommand(key, old))
+			lines = append(lines, c.UnsetCommand(SavedVarPrefix+key))
+		} else {
+			lines = append(lines, c.UnsetCommand(key))
+		}
+	}
+	lines = append(lines, c.UnsetCommand(SavedVarsKey))
+	return strings.Join(lines, "\n") + "\n", true
+}
+
+// posixQuote quotes s for sh, bash and zsh
+func posixQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// fishQuote quotes s for fish, where \ and ' are escaped inside single quotes
+func fishQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
+
+// powerShellQuote quotes s as a PowerShell verbatim string
+func powerShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,101 @@
+package shell
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetCommand(t *testing.T) {
+	tests := []struct {
+		shell    string
+		key      string
+		value    string
+		expected string
+	}{
+		{shell: "bash", key: "JAVA_HOME", value: "/opt/jdk 17", expected: "export JAVA_HOME='/opt/jdk 17'"},
+		{shell: "zsh", key: "JAVA_HOME", value: "/opt/it's", expected: `export JAVA_HOME='/opt/it'\''s'`},
+		{shell: "sh", key: "JAVA_HOME", value: "/opt/jdk", expected: "export JAVA_HOME='/opt/jdk'"},
+		{shell: "fish", key: "JAVA_HOME", value: "/opt/it's", expected: `set -gx JAVA_HOME '/opt/it\'s'`},
+		{shell: "pwsh", key: "JAVA_HOME", value: "C:\\it's", expected: `$env:JAVA_HOME = 'C:\it''s'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			sh, err := GetSessionShellConfig(tt.shell)
+			if err != nil {
+				t.Fatalf("获取 shell 配置失败: %v", err)
+			}
+			if got := sh.SetCommand(tt.key, tt.value); got != tt.expected {
+				t.Errorf("期望 %s，实际 %s", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := GetSessionShellConfig("tcsh"); err == nil {
+		t.Error("期望不支持 tcsh")
+	}
+}
+
+func TestActivateAndDeactivate(t *testing.T) {
+	sh, _ := GetSessionShellConfig("bash")
+	env := map[string]string{"PATH": "/usr/bin", "JENV_VERSION": "21"}
+	lookup := func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+	vars := []EnvVar{
+		{Key: "JAVA_HOME", Value: "/opt/jdk17"},
+		{Key: "PATH", Value: "/opt/jdk17/bin:/usr/bin"},
+		{Key: "JENV_VERSION", Value: "jdk17"},
+	}
+
+	script := ActivateScript(sh, vars, lookup)
+	for _, line := range []string{
+		"export JENV_SHELL_OLD_PATH='/usr/bin'",
+		"export JENV_SHELL_OLD_JENV_VERSION='21'",
+		"export JENV_SHELL_SAVED='JAVA_HOME PATH JENV_VERSION'",
+		"export JAVA_HOME='/opt/jdk17'",
+	} {
+		if !strings.Contains(script, line+"\n") {
+			t.Errorf("缺少 %q:\n%s", line, script)
+		}
+	}
+	if strings.Contains(script, "JENV_SHELL_OLD_JAVA_HOME") {
+		t.Error("未设置的变量不应保存")
+	}
+
+	// 模拟执行激活脚本后的会话
+	env = map[string]string{
+		"JAVA_HOME":                   "/opt/jdk17",
+		"PATH":                        "/opt/jdk17/bin:/usr/bin",
+		"JENV_VERSION":                "jdk17",
+		"JENV_SHELL_OLD_PATH":         "/usr/bin",
+		"JENV_SHELL_OLD_JENV_VERSION": "21",
+		SavedVarsKey:                  "JAVA_HOME PATH JENV_VERSION",
+	}
+
+	// 再次切换不应覆盖保存的原值
+	if again := ActivateScript(sh, vars, lookup); strings.Contains(again, SavedVarPrefix) {
+		t.Errorf("重复切换不应再次保存:\n%s", again)
+	}
+
+	restore, ok := DeactivateScript(sh, lookup)
+	if !ok {
+		t.Fatal("期望存在会话")
+	}
+	expected := strings.Join([]string{
+		"unset JAVA_HOME",
+		"export PATH='/usr/bin'",
+		"unset JENV_SHELL_OLD_PATH",
+		"export JENV_VERSION='21'",
+		"unset JENV_SHELL_OLD_JENV_VERSION",
+		"unset JENV_SHELL_SAVED",
+	}, "\n") + "\n"
+	if restore != expected {
+		t.Errorf("恢复脚本错误:\n期望\n%s\n实际\n%s", expected, restore)
+	}
+
+	env = map[string]string{}
+	if _, ok := DeactivateScript(sh, lookup); ok {
+		t.Error("没有会话时不应生成恢复脚本")
+	}
+}
@@ -1,5 +1,3 @@
-//go:build !windows
-
 package shell
 
 import (
@@ -9,9 +7,10 @@ import (
 	"strings"
 )
 
-// This package provides shell environment management for Unix-like systems only.
-// Windows uses registry-based environment variable management and does not need
-// shell configuration file support.
+// This package provides shell environment management. Configuration files are
+// only managed on Unix-like systems; Windows uses registry-based environment
+// variable management. Session code for 'jenv shell' is generated on all
+// platforms, including PowerShell.
 
 // ShellType represents different shell types
 type ShellType string
@@ -21,6 +20,8 @@ const (
 	Zsh     ShellType = "zsh"
 	Fish    ShellType = "fish"
 	Profile ShellType = "profile"
+	// PowerShell is only used for session code, its profile is not managed by jenv
+	PowerShell ShellType = "pwsh"
 )
 
 // ShellConfig represents shell-specific configuration
@@ -0,0 +1,13 @@
+//go:build !windows
+
+package sys
+
+import (
+	"syscall"
+)
+
+// Exec replaces the current process with the program at path.
+// args[0] is the program name; on success Exec does not return.
+func Exec(path string, args []string, env []string) error {
+	return syscall.Exec(path, args, env)
+}
@@ -0,0 +1,7 @@
+//go:build darwin || freebsd || netbsd || openbsd || dragonfly
+
+package sys
+
+import "golang.org/x/sys/unix"
+
+const ioctlReadTermios = unix.TIOCGETA
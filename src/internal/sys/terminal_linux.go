@@ -0,0 +1,7 @@
+//go:build linux
+
+package sys
+
+import "golang.org/x/sys/unix"
+
+const ioctlReadTermios = unix.TCGETS
@@ -0,0 +1,12 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package sys
+
+import "golang.org/x/sys/unix"
+
+// IsTerminal reports whether fd refers to an interactive terminal. Character
+// devices such as /dev/null are not terminals.
+func IsTerminal(fd uintptr) bool {
+	_, err := unix.IoctlGetTermios(int(fd), ioctlReadTermios)
+	return err == nil
+}
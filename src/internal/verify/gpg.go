@@ -0,0 +1,50 @@
+package verify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// gpgvCommand is the verifier run for GPG signatures; gpgv only trusts the keyrings it is given
+var gpgvCommand = "gpgv"
+
+// verifyGPG checks a detached GPG signature with gpgv against each configured keyring
+func verifyGPG(path, sig string, keyrings []string, res *Result) error {
+	if len(keyrings) == 0 {
+		return fmt.Errorf("%w: add keyring files to verify.gpg_keyrings in config.json", ErrNoTrustedKey)
+	}
+	bin, err := exec.LookPath(gpgvCommand)
+	if err != nil {
+		return fmt.Errorf("cannot verify GPG signature: %s not found in PATH", gpgvCommand)
+	}
+
+	var lastOutput string
+	for _, keyring := range keyrings {
+		// gpgv 会把不含路径的 keyring 当作 ~/.gnupg 下的文件，这里统一转成绝对路径
+		if abs, err := filepath.Abs(keyring); err == nil {
+			keyring = abs
+		}
+		var out bytes.Buffer
+		cmd := exec.Command(bin, "--keyring", keyring, sig, path)
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+		err := cmd.Run()
+		if err == nil {
+			res.SignedBy = "GPG keyring " + keyring
+			return nil
+		}
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return err
+		}
+		lastOutput = strings.TrimSpace(out.String())
+	}
+	if lastOutput != "" {
+		return fmt.Errorf("%w: %s", ErrBadSignature, lastOutput)
+	}
+	return ErrBadSignature
+}
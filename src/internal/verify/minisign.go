@@ -0,0 +1,157 @@
+package verify
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// minisign 格式: https://jedisct1.github.io/minisign/
+// 公钥为 "Ed" + 8 字节 key id + 32 字节 Ed25519 公钥，签名为算法 + key id + 64 字节签名，
+// 全局签名覆盖签名本身和可信注释。"ED" 表示对 BLAKE2b-512 摘要签名，"Ed" 为旧格式直接签名文件内容。
+
+const (
+	minisignCommentPrefix = "untrusted comment:"
+	minisignTrustedPrefix = "trusted comment: "
+)
+
+// legacyMinisignMaxSize limits the files checked against legacy "Ed" signatures,
+// which sign the whole content and so need it in memory
+const legacyMinisignMaxSize = 64 << 20
+
+type minisignKey struct {
+	id  [8]byte
+	key ed25519.PublicKey
+}
+
+type minisignSig struct {
+	alg       string
+	id        [8]byte
+	sig       []byte
+	trusted   string
+	globalSig []byte
+}
+
+// keyID formats a key id the way minisign prints it
+func keyID(id [8]byte) string {
+	var rev [8]byte
+	for i := range id {
+		rev[i] = id[7-i]
+	}
+	return strings.ToUpper(hex.EncodeToString(rev[:]))
+}
+
+// parseMinisignKey accepts the base64 key line of a .pub file, or the path of the file
+func parseMinisignKey(value string) (minisignKey, error) {
+	var k minisignKey
+	value = strings.TrimSpace(value)
+	if data, err := os.ReadFile(value); err == nil {
+		value = ""
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, minisignCommentPrefix) {
+				value = line
+				break
+			}
+		}
+	}
+	raw, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || len(raw) != 2+8+ed25519.PublicKeySize || string(raw[:2]) != "Ed" {
+		return k, fmt.Errorf("invalid minisign public key %q", value)
+	}
+	copy(k.id[:], raw[2:10])
+	k.key = ed25519.PublicKey(raw[10:])
+	return k, nil
+}
+
+func parseMinisignSig(data []byte) (minisignSig, error) {
+	var s minisignSig
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if len(lines) < 4 || !strings.HasPrefix(lines[2], minisignTrustedPrefix) {
+		return s, fmt.Errorf("%w: malformed minisign signature", ErrBadSignature)
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(raw) != 2+8+ed25519.SignatureSize {
+		return s, fmt.Errorf("%w: malformed minisign signature", ErrBadSignature)
+	}
+	s.alg = string(raw[:2])
+	copy(s.id[:], raw[2:10])
+	s.sig = raw[10:]
+	s.trusted = strings.TrimPrefix(lines[2], minisignTrustedPrefix)
+	s.globalSig, err = base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || len(s.globalSig) != ed25519.SignatureSize {
+		return s, fmt.Errorf("%w: malformed minisign global signature", ErrBadSignature)
+	}
+	return s, nil
+}
+
+// verifyMinisign checks a minisign signature of the file at path with the trusted key
+// whose id matches the signature
+func verifyMinisign(path string, data []byte, keys []string, res *Result) error {
+	sig, err := parseMinisignSig(data)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("%w: add minisign public keys to verify.minisign_keys in config.json", ErrNoTrustedKey)
+	}
+	var key *minisignKey
+	for _, value := range keys {
+		k, err := parseMinisignKey(value)
+		if err != nil {
+			return err
+		}
+		if k.id == sig.id {
+			key = &k
+			break
+		}
+	}
+	if key == nil {
+		return fmt.Errorf("%w: signed with key %s, which is not in verify.minisign_keys", ErrNoTrustedKey, keyID(sig.id))
+	}
+
+	var message []byte
+	switch sig.alg {
+	case "ED":
+		h, _ := blake2b.New512(nil)
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		message = h.Sum(nil)
+	case "Ed":
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if info.Size() > legacyMinisignMaxSize {
+			return fmt.Errorf("%w: legacy minisign signatures are only checked for files up to %d MiB, sign with 'minisign -S -H'",
+				ErrBadSignature, legacyMinisignMaxSize>>20)
+		}
+		if message, err = os.ReadFile(path); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: unsupported minisign algorithm %q", ErrBadSignature, sig.alg)
+	}
+	if !ed25519.Verify(key.key, message, sig.sig) {
+		return fmt.Errorf("%w: minisign signature does not match with key %s", ErrBadSignature, keyID(key.id))
+	}
+	global := append(append([]byte{}, sig.sig...), sig.trusted...)
+	if !ed25519.Verify(key.key, global, sig.globalSig) {
+		return fmt.Errorf("%w: trusted comment was modified", ErrBadSignature)
+	}
+	res.SignedBy = "minisign key " + keyID(key.id)
+	res.TrustedComment = sig.trusted
+	return nil
+}
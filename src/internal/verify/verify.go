@@ -0,0 +1,202 @@
+// Package verify checks JDK archives before they are extracted: a SHA-256 checksum
+// and, when trusted keys are configured, a detached minisign or GPG signature.
+package verify
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/whywhathow/jenv/internal/config"
+)
+
+var (
+	// ErrChecksumMismatch is returned when an archive does not match its expected SHA-256
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	// ErrBadSignature is returned when a signature does not verify against a trusted key
+	ErrBadSignature = errors.New("signature verification failed")
+	// ErrNoTrustedKey is returned when a signature is present but no key to check it is configured
+	ErrNoTrustedKey = errors.New("no trusted key configured")
+	// ErrUnsigned is returned when require_signature is set and the archive has no signature
+	ErrUnsigned = errors.New("archive is not signed")
+)
+
+// 签名文件的后缀，按顺序查找
+var signatureExts = []string{".minisig", ".sig", ".asc"}
+
+// ChecksumExt is the suffix of checksum files published next to archives
+const ChecksumExt = ".sha256"
+
+// Options select what Archive checks
+type Options struct {
+	SHA256    string // 期望的 SHA-256，为空时读取 <archive>.sha256
+	Signature string // 分离签名文件，为空时查找 .minisig、.sig 或 .asc
+	Keys      config.VerifySettings
+}
+
+// Result reports what Archive checked
+type Result struct {
+	SHA256         string // 压缩包实际的 SHA-256
+	ChecksumSource string // 期望值的来源，为空表示没有校验
+	Signature      string // 已验证的签名文件，为空表示没有签名
+	SignedBy       string // 验证签名所用的公钥或公钥环
+	TrustedComment string // minisign 签名中的可信注释
+}
+
+// Verified reports whether a checksum or a signature was checked. Archive succeeds
+// without checking either when there is nothing to check against.
+func (r Result) Verified() bool {
+	return r.ChecksumSource != "" || r.Signature != ""
+}
+
+// Settings returns the trusted keys from config.json, or none when it cannot be read
+func Settings() config.VerifySettings {
+	if cfg, err := config.GetInstance(); err == nil {
+		return cfg.Verify
+	}
+	return config.VerifySettings{}
+}
+
+// Archive checks the file at path against its expected checksum and signature.
+// Checks without an expectation are skipped, unless opts.Keys.RequireSignature
+// makes a signature mandatory. Callers extracting archives should abort on any error.
+func Archive(path string, opts Options) (Result, error) {
+	var res Result
+	sum, err := SHA256File(path)
+	if err != nil {
+		return res, err
+	}
+	res.SHA256 = sum
+
+	expected, source := opts.SHA256, "--sha256"
+	if expected == "" {
+		sidecar := path + ChecksumExt
+		if expected, err = ReadChecksumFile(sidecar, path); err == nil {
+			source = sidecar
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return res, err
+		}
+	}
+	if expected != "" {
+		if err := Checksum(sum, expected); err != nil {
+			return res, fmt.Errorf("%s: %w", path, err)
+		}
+		res.ChecksumSource = source
+	}
+
+	sig := opts.Signature
+	if sig == "" {
+		sig = findSignature(path)
+	}
+	if sig == "" {
+		if opts.Keys.RequireSignature {
+			return res, fmt.Errorf("%s: %w (require_signature is set)", path, ErrUnsigned)
+		}
+		return res, nil
+	}
+	if err := verifySignature(path, sig, opts.Keys, &res); err != nil {
+		return res, fmt.Errorf("%s: %w", sig, err)
+	}
+	res.Signature = sig
+	return res, nil
+}
+
+// SHA256File returns the hex SHA-256 of a file
+func SHA256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Checksum compares a computed hex SHA-256 with an expected one, ignoring case
+func Checksum(sum, expected string) error {
+	expected = strings.TrimSpace(expected)
+	if len(expected) != sha256.Size*2 {
+		return fmt.Errorf("invalid SHA-256 %q: expected 64 hex digits", expected)
+	}
+	if _, err := hex.DecodeString(expected); err != nil {
+		return fmt.Errorf("invalid SHA-256 %q: %v", expected, err)
+	}
+	if !strings.EqualFold(sum, expected) {
+		return fmt.Errorf("%w: sha256 is %s, expected %s", ErrChecksumMismatch, sum, strings.ToLower(expected))
+	}
+	return nil
+}
+
+// ReadChecksumFile reads the expected SHA-256 for archive from a checksum file. Both a
+// bare digest and the "<digest>  <file>" lines written by sha256sum are accepted; a
+// file listing several archives must name this one.
+func ReadChecksumFile(path, archive string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	base := baseName(archive)
+	var digests []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		// sha256sum 二进制模式会在文件名前加 '*'
+		if len(fields) > 1 && baseName(strings.TrimPrefix(fields[1], "*")) == base {
+			return fields[0], nil
+		}
+		digests = append(digests, fields[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	switch len(digests) {
+	case 0:
+		return "", fmt.Errorf("%s: no checksum found", path)
+	case 1:
+		// 只有一条记录时不要求文件名一致，下载后改名的压缩包也能校验
+		return digests[0], nil
+	}
+	return "", fmt.Errorf("%s: no checksum for %s", path, base)
+}
+
+func findSignature(path string) string {
+	for _, ext := range signatureExts {
+		if info, err := os.Stat(path + ext); err == nil && info.Mode().IsRegular() {
+			return path + ext
+		}
+	}
+	return ""
+}
+
+// verifySignature dispatches on the signature format: minisign files start with an
+// untrusted comment line, anything else is handed to gpgv.
+func verifySignature(path, sig string, keys config.VerifySettings, res *Result) error {
+	data, err := os.ReadFile(sig)
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(string(data), minisignCommentPrefix) {
+		return verifyMinisign(path, data, keys.MinisignKeys, res)
+	}
+	return verifyGPG(path, sig, keys.GPGKeyrings, res)
+}
+
+func baseName(path string) string {
+	if i := strings.LastIndexAny(path, `/\`); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
@@ -0,0 +1,166 @@
+package verify
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/whywhathow/jenv/internal/config"
+	"golang.org/x/crypto/blake2b"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestArchiveChecksum(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "jdk.tar.gz")
+	writeFile(t, archive, "jdk")
+	sum := sha256.Sum256([]byte("jdk"))
+	digest := hex.EncodeToString(sum[:])
+
+	res, err := Archive(archive, Options{})
+	if err != nil || res.ChecksumSource != "" || res.SHA256 != digest || res.Verified() {
+		t.Fatalf("没有期望值时应跳过校验: %+v, %v", res, err)
+	}
+
+	if _, err := Archive(archive, Options{SHA256: strings.ToUpper(digest)}); err != nil {
+		t.Errorf("--sha256 应忽略大小写: %v", err)
+	}
+	if _, err := Archive(archive, Options{SHA256: strings.Repeat("0", 64)}); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("期望 ErrChecksumMismatch，实际 %v", err)
+	}
+	if _, err := Archive(archive, Options{SHA256: "abc"}); err == nil || errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("格式错误的 SHA-256 应单独报错，实际 %v", err)
+	}
+
+	sidecar := archive + ChecksumExt
+	writeFile(t, sidecar, strings.Repeat("f", 64)+"  other.tar.gz\n"+digest+" *jdk.tar.gz\n")
+	if res, err := Archive(archive, Options{}); err != nil || res.ChecksumSource != sidecar || !res.Verified() {
+		t.Errorf("应使用 .sha256 中对应文件名的记录: %+v, %v", res, err)
+	}
+	writeFile(t, sidecar, strings.Repeat("f", 64)+"  other.tar.gz\n"+digest+"  another.tar.gz\n")
+	if _, err := Archive(archive, Options{}); err == nil {
+		t.Error("多条记录都不匹配文件名时应报错")
+	}
+	writeFile(t, sidecar, strings.Repeat("f", 64)+"\n")
+	if _, err := Archive(archive, Options{}); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("期望 .sha256 不匹配时报 ErrChecksumMismatch，实际 %v", err)
+	}
+}
+
+// minisignFixture signs data the way minisign does and returns the public key line
+// and the .minisig content
+func minisignFixture(t *testing.T, alg string, data []byte, trusted string) (string, string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := make([]byte, 8)
+	rand.Read(id)
+
+	message := data
+	if alg == "ED" {
+		sum := blake2b.Sum512(data)
+		message = sum[:]
+	}
+	sig := ed25519.Sign(priv, message)
+	global := ed25519.Sign(priv, append(append([]byte{}, sig...), trusted...))
+
+	key := base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), id...), pub...))
+	blob := base64.StdEncoding.EncodeToString(append(append([]byte(alg), id...), sig...))
+	return key, "untrusted comment: signature from minisign secret key\n" + blob + "\n" +
+		minisignTrustedPrefix + trusted + "\n" + base64.StdEncoding.EncodeToString(global) + "\n"
+}
+
+func TestArchiveMinisign(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "jdk.zip")
+	data := []byte(strings.Repeat("jdk archive ", 100))
+	if err := os.WriteFile(archive, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, alg := range []string{"ED", "Ed"} {
+		key, sig := minisignFixture(t, alg, data, "timestamp:1700000000\tfile:jdk.zip")
+		writeFile(t, archive+".minisig", sig)
+
+		res, err := Archive(archive, Options{Keys: config.VerifySettings{MinisignKeys: []string{key}}})
+		if err != nil {
+			t.Fatalf("%s: 签名应验证通过: %v", alg, err)
+		}
+		if res.Signature != archive+".minisig" || res.TrustedComment != "timestamp:1700000000\tfile:jdk.zip" {
+			t.Errorf("%s: 结果不对: %+v", alg, res)
+		}
+
+		// 公钥也可以是 .pub 文件路径
+		pubFile := filepath.Join(dir, "key.pub")
+		writeFile(t, pubFile, "untrusted comment: minisign public key\n"+key+"\n")
+		if _, err := Archive(archive, Options{Keys: config.VerifySettings{MinisignKeys: []string{pubFile}}}); err != nil {
+			t.Errorf("%s: .pub 文件路径应可用: %v", alg, err)
+		}
+
+		if _, err := Archive(archive, Options{}); !errors.Is(err, ErrNoTrustedKey) {
+			t.Errorf("%s: 没有配置公钥时期望 ErrNoTrustedKey，实际 %v", alg, err)
+		}
+		otherKey, _ := minisignFixture(t, alg, data, "")
+		if _, err := Archive(archive, Options{Keys: config.VerifySettings{MinisignKeys: []string{otherKey}}}); !errors.Is(err, ErrNoTrustedKey) {
+			t.Errorf("%s: 其他公钥签名时期望 ErrNoTrustedKey，实际 %v", alg, err)
+		}
+
+		tampered := strings.Replace(sig, "file:jdk.zip", "file:evil.zip", 1)
+		writeFile(t, archive+".minisig", tampered)
+		if _, err := Archive(archive, Options{Keys: config.VerifySettings{MinisignKeys: []string{key}}}); !errors.Is(err, ErrBadSignature) {
+			t.Errorf("%s: 可信注释被改动时期望 ErrBadSignature，实际 %v", alg, err)
+		}
+
+		writeFile(t, archive+".minisig", sig)
+		if err := os.WriteFile(archive, append(data, '!'), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Archive(archive, Options{Keys: config.VerifySettings{MinisignKeys: []string{key}}}); !errors.Is(err, ErrBadSignature) {
+			t.Errorf("%s: 文件被改动时期望 ErrBadSignature，实际 %v", alg, err)
+		}
+		if err := os.WriteFile(archive, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestArchiveRequireSignature(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "jdk.tar.gz")
+	writeFile(t, archive, "jdk")
+	_, err := Archive(archive, Options{Keys: config.VerifySettings{RequireSignature: true}})
+	if !errors.Is(err, ErrUnsigned) {
+		t.Errorf("期望 ErrUnsigned，实际 %v", err)
+	}
+}
+
+func TestArchiveMinisignLegacyTooLarge(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "jdk.tar.gz")
+	key, sig := minisignFixture(t, "Ed", []byte("jdk"), "file:jdk.tar.gz")
+	writeFile(t, archive+".minisig", sig)
+	// 稀疏文件，不占用实际空间
+	if err := os.WriteFile(archive, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(archive, legacyMinisignMaxSize+1); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Archive(archive, Options{Keys: config.VerifySettings{MinisignKeys: []string{key}}})
+	if !errors.Is(err, ErrBadSignature) || !strings.Contains(err.Error(), "legacy") {
+		t.Errorf("超过大小限制的旧格式签名应被拒绝，实际 %v", err)
+	}
+}